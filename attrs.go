@@ -0,0 +1,106 @@
+package log
+
+import (
+	"log/slog"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// tagName is the struct tag consulted by [StructAttrs].
+	tagName = "log"
+
+	// redacted is the value shown in place of anything marked for redaction.
+	redacted = "REDACTED"
+
+	// mask replaces the hidden part of a value passed to [Masked].
+	mask = "***"
+)
+
+// Masked returns a [slog.Attr] showing only the first keep characters of value, with the
+// rest replaced by "***", for values like tokens, emails and IDs that are useful to
+// recognise but shouldn't be logged in full:
+//
+//	logger.Info("Authenticated", log.Masked("user", "abcdef@example.com", 2))
+//	// ... INFO:  Authenticated user=ab***
+//
+// The mask is the same whatever the length of the hidden part, so it doesn't leak the
+// value's length. If value is no longer than keep, it's masked entirely.
+func Masked(key, value string, keep int) slog.Attr {
+	if keep <= 0 || utf8.RuneCountInString(value) <= keep {
+		return slog.String(key, mask)
+	}
+
+	end := 0
+	for range keep {
+		_, size := utf8.DecodeRuneInString(value[end:])
+		end += size
+	}
+
+	return slog.String(key, value[:end]+mask)
+}
+
+// StructAttrs returns a [slog.Attr] for each exported field of the struct v
+// that carries a `log` struct tag, in field order.
+//
+// The tag value is the key to use, falling back to the field name if empty. Fields
+// tagged `log:"-"` are skipped, as are untagged and unexported fields. A ",redact"
+// option (e.g. `log:"password,redact"`) keeps the key but replaces the value with
+// "REDACTED".
+//
+//	type Config struct {
+//		Host     string `log:"host"`
+//		Port     int    `log:"port"`
+//		Password string `log:"password,redact"`
+//		Internal string `log:"-"`
+//	}
+//
+//	logger.Info("Loaded config", log.StructAttrs(cfg)...)
+//
+// v may be a struct or a pointer to one, anything else (including a nil pointer)
+// returns nil.
+func StructAttrs(v any) []slog.Attr {
+	val := reflect.ValueOf(v)
+	for val.Kind() == reflect.Pointer {
+		if val.IsNil() {
+			return nil
+		}
+
+		val = val.Elem()
+	}
+
+	if val.Kind() != reflect.Struct {
+		return nil
+	}
+
+	typ := val.Type()
+
+	var attrs []slog.Attr
+
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag, ok := field.Tag.Lookup(tagName)
+		if !ok || tag == "-" {
+			continue
+		}
+
+		key, options, _ := strings.Cut(tag, ",")
+		if key == "" {
+			key = field.Name
+		}
+
+		if options == "redact" {
+			attrs = append(attrs, slog.String(key, redacted))
+			continue
+		}
+
+		attrs = append(attrs, slog.Any(key, val.Field(i).Interface()))
+	}
+
+	return attrs
+}
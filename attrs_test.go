@@ -0,0 +1,107 @@
+package log_test
+
+import (
+	"log/slog"
+	"testing"
+
+	"go.followtheprocess.codes/log"
+	"go.followtheprocess.codes/test"
+)
+
+func TestStructAttrs(t *testing.T) {
+	type config struct {
+		Host     string `log:"host"`
+		Password string `log:"password,redact"`
+		Internal string `log:"-"`
+		Untagged string
+		Named    bool `log:""`
+		hidden   string
+		Port     int `log:"port"`
+	}
+
+	cfg := config{
+		Host:     "localhost",
+		Port:     8080,
+		Password: "hunter2",
+		Internal: "nope",
+		Untagged: "nope",
+		Named:    true,
+		hidden:   "nope",
+	}
+
+	tests := []struct {
+		value any         // The value to pass to StructAttrs
+		name  string      // Name of the test case
+		want  []slog.Attr // Expected attrs
+	}{
+		{
+			name:  "struct",
+			value: cfg,
+			want: []slog.Attr{
+				slog.String("host", "localhost"),
+				slog.String("password", "REDACTED"),
+				slog.Bool("Named", true),
+				slog.Int("port", 8080),
+			},
+		},
+		{
+			name:  "pointer",
+			value: &cfg,
+			want: []slog.Attr{
+				slog.String("host", "localhost"),
+				slog.String("password", "REDACTED"),
+				slog.Bool("Named", true),
+				slog.Int("port", 8080),
+			},
+		},
+		{
+			name:  "nil pointer",
+			value: (*config)(nil),
+			want:  nil,
+		},
+		{
+			name:  "not a struct",
+			value: "hello",
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := log.StructAttrs(tt.value)
+
+			test.Equal(t, len(got), len(tt.want), test.Context("wrong number of attrs"))
+
+			for i := range got {
+				test.True(t, got[i].Equal(tt.want[i]), test.Context("attr %d: got %v, wanted %v", i, got[i], tt.want[i]))
+			}
+		})
+	}
+}
+
+func TestMasked(t *testing.T) {
+	tests := []struct {
+		name  string // Name of the test case
+		value string // The value to mask
+		want  string // Expected attr value
+		keep  int    // Number of characters to keep
+	}{
+		{name: "long", value: "abcdef@example.com", keep: 2, want: "ab***"},
+		{name: "one more than keep", value: "abc", keep: 2, want: "ab***"},
+		{name: "same as keep", value: "ab", keep: 2, want: "***"},
+		{name: "shorter than keep", value: "a", keep: 2, want: "***"},
+		{name: "empty", value: "", keep: 2, want: "***"},
+		{name: "keep none", value: "secret", keep: 0, want: "***"},
+		{name: "negative keep", value: "secret", keep: -1, want: "***"},
+		{name: "multi byte", value: "héllo", keep: 2, want: "hé***"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := log.Masked("key", tt.value, tt.keep)
+
+			test.Equal(t, got.Key, "key")
+			test.Equal(t, got.Value.String(), tt.want)
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package log
+
+import "log/slog"
+
+// BatchLogger logs a burst of lines on behalf of a [Logger], see [Logger.Batch].
+//
+// It must only be used within the function passed to Batch.
+type BatchLogger struct {
+	logger *Logger     // The logger the batch belongs to
+	buf    []byte      // The rendered lines, back to back
+	lines  []batchLine // Where each line in buf ends, and its level
+}
+
+// batchLine is a single line in a [BatchLogger]'s buffer.
+type batchLine struct {
+	end   int   // The index in the buffer just after the end of the line
+	level Level // The level the line was logged at
+}
+
+// Batch calls fn with a [BatchLogger] whose lines are collected up and written together,
+// with a single write under a single acquisition of the logger's lock, once fn returns:
+//
+//	logger.Batch(func(b *log.BatchLogger) {
+//		for _, row := range table {
+//			b.Info("Row", slog.String("name", row.Name), slog.Int("count", row.Count))
+//		}
+//	})
+//
+// This is cheaper than logging a burst of lines one at a time and guarantees they aren't
+// interleaved with lines logged concurrently by other goroutines. Lines are timestamped
+// when they're logged, not when the batch is written.
+//
+// Loggers that don't write rendered lines to a single writer, i.e. those from
+// [NewChannel], [NewDual] and [NewTee], log each line as usual rather than batching them.
+func (l *Logger) Batch(fn func(b *BatchLogger)) {
+	bufp := getBuffer(l.freeList, l.bufferHint)
+	defer putBuffer(l.freeList, bufp)
+
+	b := &BatchLogger{logger: l, buf: *bufp}
+
+	fn(b)
+
+	b.flush()
+
+	// Put it back, it may well have grown
+	*bufp = b.buf
+}
+
+// Debug adds a debug level log line to the batch.
+func (b *BatchLogger) Debug(msg string, attrs ...slog.Attr) {
+	b.log(LevelDebug, msg, attrs)
+}
+
+// Info adds an info level log line to the batch.
+func (b *BatchLogger) Info(msg string, attrs ...slog.Attr) {
+	b.log(LevelInfo, msg, attrs)
+}
+
+// Warn adds a warning level log line to the batch.
+func (b *BatchLogger) Warn(msg string, attrs ...slog.Attr) {
+	b.log(LevelWarn, msg, attrs)
+}
+
+// Error adds an error level log line to the batch.
+func (b *BatchLogger) Error(msg string, attrs ...slog.Attr) {
+	b.log(LevelError, msg, attrs)
+}
+
+// Log adds a log line at the given level to the batch.
+func (b *BatchLogger) Log(level Level, msg string, attrs ...slog.Attr) {
+	b.log(level, msg, attrs)
+}
+
+// log renders a log line into the batch's buffer, if its level is enabled.
+func (b *BatchLogger) log(level Level, msg string, attrs []slog.Attr) {
+	l := b.logger
+	if l.isDiscard || !l.enabled(level) {
+		return
+	}
+
+	if l.records != nil || l.sinks != nil || l.jsonW != nil {
+		l.write(level, msg, attrs)
+		return
+	}
+
+	if !l.claimOnce() {
+		return
+	}
+
+	var extra [extraAttrs]slog.Attr
+
+	rec := l.newRecord(l.timeFunc(), level, msg, attrs, extra[:0])
+
+	b.buf = l.render(b.buf, &rec)
+	b.lines = append(b.lines, batchLine{end: len(b.buf), level: level})
+}
+
+// flush writes the batch's lines under the logger's lock, calling the line hook for
+// each one first if there is one.
+func (b *BatchLogger) flush() {
+	if len(b.lines) == 0 {
+		return
+	}
+
+	l := b.logger
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	start := 0
+	for _, line := range b.lines {
+		if l.lineHook != nil {
+			l.lineHook(line.level, b.buf[start:line.end])
+		}
+
+		// Captured lines are kept one by one
+		if l.captured != nil {
+			l.writeLocked(b.buf[start:line.end])
+		}
+
+		start = line.end
+	}
+
+	if l.captured == nil {
+		l.writeLocked(b.buf)
+	}
+}
@@ -0,0 +1,140 @@
+package log_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go.followtheprocess.codes/hue"
+	"go.followtheprocess.codes/log"
+	"go.followtheprocess.codes/test"
+)
+
+func TestBatch(t *testing.T) {
+	hue.Enabled(false) // Force no color
+
+	fixedTime := func() time.Time {
+		return time.Date(2025, time.April, 1, 13, 34, 3, 0, time.UTC)
+	}
+
+	t.Run("single write", func(t *testing.T) {
+		w := &countingWriter{}
+		logger := log.New(w, log.TimeFunc(fixedTime), log.WithLevel(log.LevelInfo))
+
+		logger.Batch(func(b *log.BatchLogger) {
+			b.Info("One", slog.Int("n", 1))
+			b.Debug("Hidden")
+			b.Warn("Two")
+			b.Log(log.LevelError, "Three")
+		})
+
+		want := "2025-04-01T13:34:03Z INFO:  One n=1\n" +
+			"2025-04-01T13:34:03Z WARN:  Two\n" +
+			"2025-04-01T13:34:03Z ERROR: Three\n"
+
+		test.Diff(t, w.buf.String(), want)
+		test.Equal(t, w.writes, 1)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		w := &countingWriter{}
+		logger := log.New(w)
+
+		logger.Batch(func(b *log.BatchLogger) {
+			b.Debug("Hidden")
+		})
+
+		test.Equal(t, w.writes, 0)
+	})
+
+	t.Run("line hook and capture", func(t *testing.T) {
+		var hooked []string
+
+		hook := func(level log.Level, line []byte) {
+			hooked = append(hooked, strings.TrimSpace(string(line)))
+		}
+
+		logger := log.New(io.Discard, log.WithPlain(), log.WithCapture(), log.WithLineHook(hook))
+
+		logger.Batch(func(b *log.BatchLogger) {
+			b.Info("One")
+			b.Error("Two")
+		})
+
+		want := []string{"INFO:  One", "ERROR: Two"}
+
+		test.EqualFunc(t, hooked, want, slices.Equal)
+		test.EqualFunc(t, logger.Captured(), want, slices.Equal)
+	})
+
+	t.Run("not interleaved", func(t *testing.T) {
+		const (
+			batches = 8
+			lines   = 20
+		)
+
+		buf := &bytes.Buffer{} // The logger's lock protects it
+		logger := log.New(buf, log.WithPlain())
+
+		var wg sync.WaitGroup
+
+		for batch := range batches {
+			wg.Go(func() {
+				logger.Batch(func(b *log.BatchLogger) {
+					for line := range lines {
+						b.Info("Batched", slog.Int("batch", batch), slog.Int("line", line))
+					}
+				})
+			})
+
+			wg.Go(func() {
+				for range lines {
+					logger.Info("Unbatched")
+				}
+			})
+		}
+
+		wg.Wait()
+
+		output := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		test.Equal(t, len(output), 2*batches*lines)
+
+		// Every batch's lines must be together and in order
+		for i := 0; i < len(output); i++ {
+			if !strings.Contains(output[i], "Batched") {
+				continue
+			}
+
+			var batch, line int
+
+			_, err := fmt.Sscanf(output[i], "INFO:  Batched batch=%d line=%d", &batch, &line)
+			test.Ok(t, err)
+			test.Equal(t, line, 0, test.Context("batch %d interleaved at line %d", batch, i))
+
+			for want := range lines {
+				got := fmt.Sprintf("INFO:  Batched batch=%d line=%d", batch, want)
+				test.Equal(t, output[i+want], got, test.Context("batch %d interleaved", batch))
+			}
+
+			i += lines - 1
+		}
+	})
+}
+
+// countingWriter is an [io.Writer] that counts the calls to Write.
+type countingWriter struct {
+	buf    bytes.Buffer
+	writes int
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	c.writes++
+
+	return c.buf.Write(p)
+}
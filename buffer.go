@@ -0,0 +1,14 @@
+package log
+
+// BufferStrategy controls how a [Logger] recycles the buffers it renders log lines
+// into, see [WithBufferStrategy].
+type BufferStrategy int
+
+// PoolUnbounded recycles buffers through a [sync.Pool], this is the default.
+const PoolUnbounded BufferStrategy = 0
+
+// FreeList returns a [BufferStrategy] that keeps at most n buffers for reuse, at
+// least 1.
+func FreeList(n int) BufferStrategy {
+	return BufferStrategy(max(n, 1))
+}
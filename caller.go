@@ -0,0 +1,60 @@
+package log
+
+import (
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+const (
+	// defaultCallerKey is the default key for the caller attr added by [WithCaller].
+	defaultCallerKey = "source"
+
+	// maxCallerDepth is the maximum number of stack frames searched for the caller
+	// of the logger, comfortably more than the logger's own call depth.
+	maxCallerDepth = 16
+)
+
+// callerPackages are the prefixes of the fully qualified names of functions skipped over
+// when looking for the caller of the logger, i.e. those of this package and log/slog
+// for logs that come through [Logger.Handler].
+//
+//nolint:gochecknoglobals // Constant lookup table
+var callerPackages = []string{"go.followtheprocess.codes/log.", "log/slog."}
+
+// caller returns the location of the code that called the logger as "file.go:line",
+// or "" if it can't be determined.
+func caller() string {
+	var pcs [maxCallerDepth]uintptr
+
+	// Skip runtime.Callers and caller itself
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs[:])])
+
+	for {
+		frame, more := frames.Next()
+
+		if !fromLogger(frame.Function) {
+			if frame.File == "" {
+				return ""
+			}
+
+			return filepath.Base(frame.File) + ":" + strconv.Itoa(frame.Line)
+		}
+
+		if !more {
+			return ""
+		}
+	}
+}
+
+// fromLogger reports whether the fully qualified function name is part of the logger.
+func fromLogger(function string) bool {
+	for _, prefix := range callerPackages {
+		if strings.HasPrefix(function, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,99 @@
+package log
+
+import (
+	"log/slog"
+	"reflect"
+	"slices"
+
+	"go.followtheprocess.codes/hue"
+)
+
+const (
+	// changeArrow joins the old and new values of a [Logger.Change] when colour is
+	// enabled, plainChangeArrow when it isn't.
+	changeArrow      = " → "
+	plainChangeArrow = " -> "
+
+	// unchangedMarker follows the value of a [Logger.Change] whose old and new
+	// values are equal.
+	unchangedMarker = "(unchanged)"
+
+	// oldJSONKey and newJSONKey are the keys of the old and new values of a
+	// [Logger.Change] in JSON output.
+	oldJSONKey = "old"
+	newJSONKey = "new"
+)
+
+// Styles for [Logger.Change].
+const (
+	oldValueStyle  = hue.Red | hue.Dim
+	newValueStyle  = hue.Green
+	unchangedStyle = hue.Dim
+)
+
+// change is the value of the attr logged by [Logger.Change].
+type change struct {
+	from any // The old value
+	to   any // The new value
+}
+
+// MarshalJSON implements [json.Marshaler], rendering the change as an object
+// with "old" and "new" members.
+func (c change) MarshalJSON() ([]byte, error) {
+	dst := []byte{'{'}
+	dst = appendJSONString(dst, oldJSONKey)
+	dst = append(dst, ':')
+	dst = appendJSONValue(dst, slog.AnyValue(c.from))
+	dst = append(dst, ',')
+	dst = appendJSONString(dst, newJSONKey)
+	dst = append(dst, ':')
+	dst = appendJSONValue(dst, slog.AnyValue(c.to))
+
+	return append(dst, '}'), nil
+}
+
+// Change writes an info level log line recording that the value of key changed from
+// one value to another, e.g. for config management or migration tools:
+//
+//	logger.Change("Updated config", "replicas", 3, 5)
+//	// ... INFO:  Updated config replicas: 3 → 5
+//
+// The old value is shown in dim red and the new one in green. Without colour the arrow
+// is written as "->" instead. If the two values are equal the value is shown once,
+// followed by "(unchanged)". With [WithJSON], the value is an object with "old" and
+// "new" members.
+func (l *Logger) Change(msg, key string, from, to any) {
+	l.log(LevelInfo, msg, slog.Any(key, change{from: from, to: to}))
+}
+
+// appendChange appends the " key: old → new" form of c to dst and returns the
+// extended slice.
+func (l *Logger) appendChange(dst []byte, key string, c change) []byte {
+	mode := l.colorMode()
+
+	dst = l.appendKey(dst, key)
+	dst = append(dst, ':', ' ')
+
+	if reflect.DeepEqual(c.from, c.to) {
+		dst = l.appendValue(dst, slog.AnyValue(c.to))
+		dst = append(dst, ' ')
+
+		return appendStyled(dst, mode, unchangedStyle, unchangedMarker)
+	}
+
+	// Render each value plain first so it can be restyled as a whole
+	start := len(dst)
+	dst = l.appendValue(dst, slog.AnyValue(c.from))
+	dst = appendStyledBytes(dst[:start], mode, oldValueStyle, slices.Clone(dst[start:]))
+
+	if colorEnabled(mode) {
+		dst = append(dst, changeArrow...)
+	} else {
+		dst = append(dst, plainChangeArrow...)
+	}
+
+	start = len(dst)
+	dst = l.appendValue(dst, slog.AnyValue(c.to))
+
+	return appendStyledBytes(dst[:start], mode, newValueStyle, slices.Clone(dst[start:]))
+}
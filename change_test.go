@@ -0,0 +1,80 @@
+package log_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"go.followtheprocess.codes/hue"
+	"go.followtheprocess.codes/log"
+	"go.followtheprocess.codes/test"
+)
+
+func TestChange(t *testing.T) {
+	hue.Enabled(false) // Force no color
+
+	fixedTime := func() time.Time {
+		return time.Date(2025, time.April, 1, 13, 34, 3, 0, time.UTC)
+	}
+
+	tests := []struct {
+		from    any          // The old value
+		to      any          // The new value
+		name    string       // Name of the test case
+		want    string       // Expected log output
+		options []log.Option // Options to construct the logger with
+	}{
+		{
+			name: "plain",
+			from: 3,
+			to:   5,
+			want: "2025-04-01T13:34:03Z INFO:  Updated replicas: 3 -> 5\n",
+		},
+		{
+			name: "quoted",
+			from: "old name",
+			to:   "new",
+			want: "2025-04-01T13:34:03Z INFO:  Updated replicas: \"old name\" -> new\n",
+		},
+		{
+			name: "unchanged",
+			from: []string{"a", "b"},
+			to:   []string{"a", "b"},
+			want: "2025-04-01T13:34:03Z INFO:  Updated replicas: \"[a b]\" (unchanged)\n",
+		},
+		{
+			name:    "colour",
+			from:    3,
+			to:      5,
+			options: []log.Option{log.WithColor(log.ColorAlways)},
+			want: "\x1b[2m2025-04-01T13:34:03Z\x1b[0m \x1b[1;36mINFO\x1b[0m:  Updated \x1b[35mreplicas\x1b[0m: " +
+				"\x1b[2;31m3\x1b[0m → \x1b[32m5\x1b[0m\n",
+		},
+		{
+			name:    "colour unchanged",
+			from:    3,
+			to:      3,
+			options: []log.Option{log.WithColor(log.ColorAlways)},
+			want: "\x1b[2m2025-04-01T13:34:03Z\x1b[0m \x1b[1;36mINFO\x1b[0m:  Updated \x1b[35mreplicas\x1b[0m: " +
+				"3 \x1b[2m(unchanged)\x1b[0m\n",
+		},
+		{
+			name:    "json",
+			from:    3,
+			to:      "five",
+			options: []log.Option{log.WithJSON()},
+			want:    `{"time":"2025-04-01T13:34:03Z","level":"INFO","msg":"Updated","replicas":{"old":3,"new":"five"}}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+
+			logger := log.New(buf, append([]log.Option{log.TimeFunc(fixedTime)}, tt.options...)...)
+			logger.Change("Updated", "replicas", tt.from, tt.to)
+
+			test.Diff(t, buf.String(), tt.want)
+		})
+	}
+}
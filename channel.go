@@ -0,0 +1,72 @@
+package log
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"slices"
+	"time"
+)
+
+// ErrDropped is passed to the handler set with [WithErrorHandler] when a [Record] is
+// dropped because the channel of a logger created with [NewChannel] is full.
+var ErrDropped = errors.New("log: record dropped, channel full")
+
+// Record is a single structured log line, as sent by a logger created with [NewChannel].
+type Record struct {
+	Time    time.Time   // The time of the log line, from the logger's [TimeFunc]
+	Prefix  string      // The logger's prefix, "" if none
+	Message string      // The log message
+	Attrs   []slog.Attr // All the attrs of the line in the order they would be rendered, owned by the receiver
+	Level   Level       // The level of the log line
+}
+
+// NewChannel returns a [Logger] that sends each log line to ch as a [Record] rather than
+// formatting and writing it, for programs with their own UI that want to render logs
+// themselves.
+//
+// Level filtering, persistent attrs, prefixes, [WithAttrLayout] and so on all apply as
+// normal but the formatting options have no effect.
+//
+// Sends never block: if ch is not ready to receive (it's unbuffered with no waiting
+// receiver, or its buffer is full), the record is dropped and the handler set with
+// [WithErrorHandler], if any, is called with [ErrDropped]. Give ch a buffer
+// large enough to absorb bursts of logging.
+//
+// The logger never closes ch. Output from [Logger.Raw] and [Logger.Separator] is discarded.
+func NewChannel(ch chan<- Record, options ...Option) *Logger {
+	logger := New(io.Discard, options...)
+	logger.isDiscard = false
+	logger.records = ch
+
+	return logger
+}
+
+// send sends a log line timestamped t to the logger's channel as a [Record], dropping
+// it if the channel isn't ready.
+func (l *Logger) send(t time.Time, level Level, msg string, attrs []slog.Attr) {
+	// The record ends up on the heap so everything in it does too, copy the
+	// caller's attrs so their (probably stack allocated) slice doesn't escape
+	var extra [extraAttrs]slog.Attr
+
+	rec := l.newRecord(t, level, msg, slices.Clone(attrs), extra[:0])
+
+	out := Record{
+		Time:    rec.time,
+		Level:   rec.level,
+		Prefix:  string(l.prefix),
+		Message: rec.msg,
+		Attrs:   slices.Concat(rec.persistent, rec.attrs, rec.dynamic, rec.trailing, rec.extra),
+	}
+
+	select {
+	case l.records <- out:
+	default:
+		if l.errorHandler != nil {
+			l.mu.Lock()
+			defer l.mu.Unlock()
+
+			l.errorHandler(ErrDropped)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package log_test
+
+import (
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"go.followtheprocess.codes/log"
+	"go.followtheprocess.codes/test"
+)
+
+func TestNewChannel(t *testing.T) {
+	fixedTime := func() time.Time {
+		return time.Date(2025, time.April, 1, 13, 34, 3, 0, time.UTC)
+	}
+
+	records := make(chan log.Record, 10)
+
+	logger := log.NewChannel(
+		records,
+		log.TimeFunc(fixedTime),
+		log.WithTrailingAttrs(slog.String("version", "1.2.3")),
+	).With(slog.String("user", "tom")).Prefixed("oven")
+
+	logger.Debug("Filtered")
+	logger.Warn("Pizza is burning!", slog.String("flavour", "pepperoni"))
+
+	test.Equal(t, len(records), 1)
+
+	got := <-records
+
+	test.Equal(t, got.Time, fixedTime())
+	test.Equal(t, got.Level, log.LevelWarn)
+	test.Equal(t, got.Prefix, "oven")
+	test.Equal(t, got.Message, "Pizza is burning!")
+
+	wantAttrs := []slog.Attr{
+		slog.String("user", "tom"),
+		slog.String("flavour", "pepperoni"),
+		slog.String("version", "1.2.3"),
+	}
+
+	test.EqualFunc(t, got.Attrs, wantAttrs, func(a, b []slog.Attr) bool {
+		if len(a) != len(b) {
+			return false
+		}
+
+		for i := range a {
+			if !a[i].Equal(b[i]) {
+				return false
+			}
+		}
+
+		return true
+	})
+}
+
+func TestNewChannelDrop(t *testing.T) {
+	var dropped []error
+
+	records := make(chan log.Record, 1)
+
+	logger := log.NewChannel(records, log.WithErrorHandler(func(err error) {
+		dropped = append(dropped, err)
+	}))
+
+	logger.Info("Delivered")
+	logger.Info("Dropped")
+	logger.Info("Also dropped")
+
+	test.Equal(t, len(dropped), 2)
+	test.True(t, errors.Is(dropped[0], log.ErrDropped))
+	test.Equal(t, (<-records).Message, "Delivered")
+}
+
+func TestNewChannelDump(t *testing.T) {
+	records := make(chan log.Record, 1)
+
+	logger := log.NewChannel(records, log.WithLevel(log.LevelDebug))
+
+	logger.Dump("Port", 8080)
+
+	test.Equal(t, len(records), 1)
+
+	got := <-records
+
+	test.Equal(t, got.Level, log.LevelDebug)
+	test.Equal(t, got.Message, "Port")
+	test.Equal(t, len(got.Attrs), 1)
+	test.True(t, got.Attrs[0].Equal(slog.Int("value", 8080)))
+}
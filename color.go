@@ -0,0 +1,128 @@
+package log
+
+import (
+	"io"
+	"os"
+
+	"go.followtheprocess.codes/hue"
+)
+
+// ColorMode controls whether a [Logger] colourises its output, see [WithColor].
+type ColorMode int
+
+const (
+	// ColorAuto defers to hue's global colour detection, which takes into account
+	// $NO_COLOR, $FORCE_COLOR, $TERM and whether stdout is a terminal, and can be
+	// overridden with [hue.Enabled]. This is the default.
+	ColorAuto ColorMode = iota
+
+	// ColorAlways always colourises output, regardless of where it's going or what
+	// has been passed to [hue.Enabled].
+	ColorAlways
+
+	// ColorNever never colourises output.
+	ColorNever
+
+	// ColorDetect colourises output only if the logger's own writer is a terminal and
+	// $NO_COLOR isn't set, unlike [ColorAuto] which looks at stdout. The result is
+	// detected when the logger is created, and again when its writer changes with
+	// [Logger.To] or on demand with [Logger.RefreshColor].
+	ColorDetect
+)
+
+const (
+	escape = "\x1b["   // The start of an ANSI escape sequence
+	reset  = "\x1b[0m" // The sequence that resets all styles
+)
+
+// RefreshColor detects again whether the logger's writer is a terminal, for the
+// [ColorDetect] mode, e.g. after the program's output has been redirected. Like
+// [Logger.SetColor], it applies to every logger sharing the writer.
+func (l *Logger) RefreshColor() {
+	l.isTTY.Store(detectColor(l.w))
+}
+
+// detectColor reports whether output to w should be colourised in [ColorDetect] mode.
+func detectColor(w io.Writer) bool {
+	return isTerminal(w) && os.Getenv("NO_COLOR") == ""
+}
+
+// appendStyled appends text to dst in the given style according to mode and
+// returns the extended slice.
+func appendStyled(dst []byte, mode ColorMode, style hue.Style, text string) []byte {
+	switch mode {
+	case ColorAlways:
+		return appendANSI(dst, style, text)
+	case ColorNever:
+		return append(dst, text...)
+	default:
+		return style.AppendString(dst, text)
+	}
+}
+
+// appendStyledBytes is like appendStyled but takes the text as a []byte.
+func appendStyledBytes(dst []byte, mode ColorMode, style hue.Style, text []byte) []byte {
+	switch mode {
+	case ColorAlways:
+		return appendANSI(dst, style, text)
+	case ColorNever:
+		return append(dst, text...)
+	default:
+		return style.AppendText(dst, text)
+	}
+}
+
+// appendANSI appends text wrapped in the escape sequences for style to dst and
+// returns the extended slice, regardless of whether hue has colour enabled.
+//
+// Invalid styles append the text unstyled.
+func appendANSI[T []byte | string](dst []byte, style hue.Style, text T) []byte {
+	start := len(dst)
+	dst = append(dst, escape...)
+
+	// Composite styles are made up of each set bit's code, low bit to high, separated by
+	// ';'. Each single bit style's code is a constant so this doesn't allocate.
+	first := true
+
+	for bit := hue.Bold; bit <= hue.BrightWhiteBackground && bit <= style; bit <<= 1 {
+		if style&bit == 0 {
+			continue
+		}
+
+		code, err := bit.Code()
+		if err != nil {
+			return append(dst[:start], text...)
+		}
+
+		if !first {
+			dst = append(dst, ';')
+		}
+
+		dst = append(dst, code...)
+		first = false
+	}
+
+	if first {
+		// No bits set, not a valid style
+		return append(dst[:start], text...)
+	}
+
+	dst = append(dst, 'm')
+	dst = append(dst, text...)
+
+	return append(dst, reset...)
+}
+
+// colorEnabled reports whether text styled according to mode is actually coloured.
+func colorEnabled(mode ColorMode) bool {
+	switch mode {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	default:
+		// hue doesn't expose its global state, but it leaves text alone when disabled
+		var scratch [scratchSize]byte
+		return len(hue.Bold.AppendString(scratch[:0], " ")) > 1
+	}
+}
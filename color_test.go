@@ -0,0 +1,234 @@
+package log_test
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"go.followtheprocess.codes/hue"
+	"go.followtheprocess.codes/log"
+	"go.followtheprocess.codes/test"
+)
+
+func TestColor(t *testing.T) {
+	// Constantly return the same time
+	fixedTime := func() time.Time {
+		return time.Date(2025, time.April, 1, 13, 34, 3, 0, time.UTC)
+	}
+
+	// logLines writes a representative set of lines to a fresh logger and returns the output.
+	logLines := func(options ...log.Option) string {
+		buf := &bytes.Buffer{}
+		options = append(options, log.TimeFunc(fixedTime), log.WithLevel(log.LevelDebug))
+		logger := log.New(buf, options...).Prefixed("oven")
+
+		logger.Debug("Preheating", slog.Int("temp", 220))
+		logger.Info("Baking", slog.String("flavour", "pepperoni"))
+		logger.Warn("Smells funny")
+		logger.Error("On fire")
+
+		return buf.String()
+	}
+
+	t.Cleanup(func() { hue.Enabled(false) })
+
+	// What hue produces when it's globally enabled
+	hue.Enabled(true)
+
+	colored := logLines()
+	test.True(t, strings.Contains(colored, "\x1b["), test.Context("expected escape codes with colour enabled"))
+
+	hue.Enabled(false)
+
+	plain := logLines()
+	test.False(t, strings.Contains(plain, "\x1b["), test.Context("expected no escape codes with colour disabled"))
+
+	t.Run("always", func(t *testing.T) {
+		hue.Enabled(false)
+		test.Diff(t, logLines(log.WithColor(log.ColorAlways)), colored)
+	})
+
+	t.Run("never", func(t *testing.T) {
+		hue.Enabled(true)
+		test.Diff(t, logLines(log.WithColor(log.ColorNever)), plain)
+	})
+
+	t.Run("auto", func(t *testing.T) {
+		hue.Enabled(false)
+		test.Diff(t, logLines(log.WithColor(log.ColorAuto)), plain)
+	})
+}
+
+func TestValueColorFunc(t *testing.T) {
+	fixedTime := func() time.Time {
+		return time.Date(2025, time.April, 1, 13, 34, 3, 0, time.UTC)
+	}
+
+	// Colour HTTP status codes by class
+	statusColor := func(key string, v slog.Value) (hue.Style, bool) {
+		if key != "status" || v.Kind() != slog.KindInt64 {
+			return 0, false
+		}
+
+		switch status := v.Int64(); {
+		case status >= 500:
+			return hue.Red, true
+		case status >= 400:
+			return hue.Yellow, true
+		case status >= 300:
+			return hue.Cyan, true
+		default:
+			return hue.Green, true
+		}
+	}
+
+	t.Cleanup(func() { hue.Enabled(false) })
+
+	tests := []struct {
+		name  string        // Name of the test case
+		want  string        // Substring expected in the output
+		attr  slog.Attr     // The attr to log
+		color log.ColorMode // The colour mode of the logger
+	}{
+		{
+			name:  "server error",
+			attr:  slog.Int("status", 500),
+			color: log.ColorAlways,
+			want:  "\x1b[35mstatus\x1b[0m=\x1b[31m500\x1b[0m\n",
+		},
+		{
+			name:  "ok",
+			attr:  slog.Int("status", 200),
+			color: log.ColorAlways,
+			want:  "\x1b[35mstatus\x1b[0m=\x1b[32m200\x1b[0m\n",
+		},
+		{
+			name:  "other key left plain",
+			attr:  slog.Int("bytes", 500),
+			color: log.ColorAlways,
+			want:  "\x1b[35mbytes\x1b[0m=500\n",
+		},
+		{
+			name:  "colour off",
+			attr:  slog.Int("status", 500),
+			color: log.ColorNever,
+			want:  " status=500\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hue.Enabled(false)
+
+			buf := &bytes.Buffer{}
+			logger := log.New(buf, log.TimeFunc(fixedTime), log.WithColor(tt.color), log.WithValueColorFunc(statusColor))
+
+			logger.Info("Request", tt.attr)
+
+			got := buf.String()
+			test.True(t, strings.HasSuffix(got, tt.want), test.Context("got %q, wanted suffix %q", got, tt.want))
+
+			if tt.color == log.ColorNever {
+				test.False(t, strings.Contains(got, "\x1b["), test.Context("expected no escape codes: %q", got))
+			}
+		})
+	}
+}
+
+func TestSetColor(t *testing.T) {
+	hue.Enabled(false)
+
+	fixedTime := func() time.Time {
+		return time.Date(2025, time.April, 1, 13, 34, 3, 0, time.UTC)
+	}
+
+	buf := &bytes.Buffer{}
+	logger := log.New(buf, log.TimeFunc(fixedTime), log.WithColor(log.ColorAlways))
+	sub := logger.Prefixed("sub")
+
+	logger.Info("Coloured")
+	sub.Info("Also coloured")
+
+	// Toggled on the child, shared by the whole family
+	sub.SetColor(log.ColorNever)
+
+	logger.Info("Plain")
+	sub.Info("Also plain")
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	test.Equal(t, len(lines), 4)
+
+	for i, line := range lines {
+		colored := strings.Contains(line, "\x1b[")
+		test.Equal(t, colored, i < 2, test.Context("line %d: %q", i, line))
+	}
+
+	test.Equal(t, logger.Config().Color, log.ColorNever)
+}
+
+func TestColorDetect(t *testing.T) {
+	hue.Enabled(false)
+	t.Setenv("NO_COLOR", "")
+
+	terminal := &bytes.Buffer{}
+	log.FakeTerminal(t, terminal)
+
+	logger := log.New(terminal, log.WithPlain(), log.WithColor(log.ColorDetect))
+	logger.Info("Coloured")
+
+	test.Equal(t, terminal.String(), "\x1b[1;36mINFO\x1b[0m:  Coloured\n")
+
+	// Swapping to a writer that isn't a terminal turns colour off for the new logger only
+	buf := &bytes.Buffer{}
+	plain := logger.To(buf)
+
+	plain.Info("Plain")
+	logger.Info("Still coloured")
+
+	test.Equal(t, buf.String(), "INFO:  Plain\n")
+	test.Equal(t, terminal.String(), "\x1b[1;36mINFO\x1b[0m:  Coloured\n\x1b[1;36mINFO\x1b[0m:  Still coloured\n")
+	test.Equal(t, plain.Config().Color, log.ColorDetect)
+
+	// The writer becomes a terminal later, nothing changes until it's refreshed
+	log.FakeTerminal(t, buf)
+	buf.Reset()
+
+	plain.Info("Not refreshed")
+	plain.RefreshColor()
+	plain.Info("Refreshed")
+
+	test.Equal(t, buf.String(), "INFO:  Not refreshed\n\x1b[1;36mINFO\x1b[0m:  Refreshed\n")
+
+	// NO_COLOR wins over a terminal
+	t.Setenv("NO_COLOR", "1")
+	buf.Reset()
+
+	plain.RefreshColor()
+	plain.Info("No colour")
+
+	test.Equal(t, buf.String(), "INFO:  No colour\n")
+}
+
+func TestToTerminalOptions(t *testing.T) {
+	hue.Enabled(false)
+
+	terminal := &bytes.Buffer{}
+	log.FakeTerminalWidth(t, terminal, 30)
+
+	file := &bytes.Buffer{}
+
+	// Clearing the line and the wrap width follow whichever writer the logger has
+	logger := log.New(file, log.WithPlain(), log.WithTerminalClearLine(), log.WithWrapWidth(0))
+	attrs := []slog.Attr{slog.String("first", "one"), slog.String("second", "two"), slog.String("third", "three")}
+
+	logger.Info("To file", attrs...)
+	logger.To(terminal).Info("To terminal", attrs...)
+	logger.To(terminal).To(file).Info("Back to file", attrs...)
+
+	test.Diff(t, file.String(), "INFO:  To file first=one second=two third=three\n"+
+		"INFO:  Back to file first=one second=two third=three\n")
+	test.Diff(t, terminal.String(), "\r\x1b[KINFO:  To terminal first=one\n"+
+		"       second=two third=three\n")
+}
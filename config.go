@@ -0,0 +1,76 @@
+package log
+
+// Format is the output format of a [Logger].
+type Format int
+
+const (
+	// FormatText is the default human readable text format.
+	FormatText Format = iota
+
+	// FormatJSON is compact JSON, one record per line, see [WithJSON].
+	FormatJSON
+
+	// FormatJSONPretty is indented JSON, see [WithJSONPretty].
+	FormatJSONPretty
+)
+
+// Config is a snapshot of the commonly tweaked configuration of a [Logger], as
+// returned by [Logger.Config] and restored with [Logger.Apply].
+//
+// It is a plain value, so it may be freely copied, compared and serialised e.g.
+// to show the effective logging configuration to a user. It deliberately doesn't
+// include the writer.
+type Config struct {
+	Prefix      string     `json:"prefix"`      // The logger's prefix, "" if none
+	TimeFormat  string     `json:"timeFormat"`  // The time format layout string, see [TimeFormat]
+	TimePreset  TimePreset `json:"timePreset"`  // A preset no layout can express e.g. [PresetUnix], used in place of TimeFormat. The zero value means use TimeFormat
+	NoTimestamp bool       `json:"noTimestamp"` // Whether the timestamp is left out of text lines, see [WithPlain]
+	Level       Level      `json:"level"`       // The minimum level, see [WithLevel]
+	Color       ColorMode  `json:"color"`       // The colour mode, see [WithColor]
+	Format      Format     `json:"format"`      // The output format
+}
+
+// Config returns a snapshot of the logger's current configuration.
+//
+//	saved := logger.Config()
+//	defer logger.Apply(saved)
+func (l *Logger) Config() Config {
+	format := FormatText
+
+	switch {
+	case l.jsonPretty:
+		format = FormatJSONPretty
+	case l.json:
+		format = FormatJSON
+	}
+
+	return Config{
+		Prefix:      string(l.prefix),
+		TimeFormat:  l.timeFormat,
+		TimePreset:  l.timePreset,
+		NoTimestamp: l.noTimestamp,
+		Level:       l.level,
+		Color:       ColorMode(l.color.Load()),
+		Format:      format,
+	}
+}
+
+// Apply sets the logger's configuration to cfg, typically one previously returned
+// from [Logger.Config].
+//
+// Only the logger itself is changed, not any loggers previously derived from it
+// with [Logger.With] or [Logger.Prefixed], except for the colour mode which is shared
+// between them (see [Logger.SetColor]).
+//
+// Apply is not safe for concurrent use, like the options passed to [New] it must not be
+// called while the logger is in use by other goroutines.
+func (l *Logger) Apply(cfg Config) {
+	l.prefix = []byte(cfg.Prefix)
+	l.timeFormat = cfg.TimeFormat
+	l.timePreset = cfg.TimePreset
+	l.noTimestamp = cfg.NoTimestamp
+	l.level = cfg.Level
+	l.SetColor(cfg.Color)
+	l.json = cfg.Format == FormatJSON || cfg.Format == FormatJSONPretty
+	l.jsonPretty = cfg.Format == FormatJSONPretty
+}
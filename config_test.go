@@ -0,0 +1,116 @@
+package log_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"go.followtheprocess.codes/hue"
+	"go.followtheprocess.codes/log"
+	"go.followtheprocess.codes/test"
+)
+
+func TestConfig(t *testing.T) {
+	hue.Enabled(false) // Force no color
+
+	fixedTime := func() time.Time {
+		return time.Date(2025, time.April, 1, 13, 34, 3, 0, time.UTC)
+	}
+
+	buf := &bytes.Buffer{}
+
+	logger := log.New(
+		buf,
+		log.TimeFunc(fixedTime),
+		log.TimeFormat(time.Kitchen),
+		log.Prefix("app"),
+		log.WithLevel(log.LevelDebug),
+		log.WithColor(log.ColorNever),
+	)
+
+	saved := logger.Config()
+
+	want := log.Config{
+		Prefix:     "app",
+		TimeFormat: time.Kitchen,
+		Level:      log.LevelDebug,
+		Color:      log.ColorNever,
+		Format:     log.FormatText,
+	}
+
+	test.Equal(t, saved, want)
+
+	// Config should be serialisable
+	raw, err := json.Marshal(saved)
+	test.Ok(t, err)
+
+	var decoded log.Config
+
+	test.Ok(t, json.Unmarshal(raw, &decoded))
+	test.Equal(t, decoded, saved)
+
+	// Mutate everything
+	logger.Apply(log.Config{
+		Prefix:     "",
+		TimeFormat: time.RFC3339,
+		Level:      log.LevelError,
+		Color:      log.ColorNever,
+		Format:     log.FormatJSON,
+	})
+
+	test.Equal(t, logger.Config().Format, log.FormatJSON)
+
+	logger.Debug("Hidden")
+	logger.Error("Mutated")
+
+	// And restore
+	logger.Apply(saved)
+	test.Equal(t, logger.Config(), saved)
+
+	logger.Debug("Restored")
+
+	wantLog := `{"time":"2025-04-01T13:34:03Z","level":"ERROR","msg":"Mutated"}` + "\n" +
+		"1:34PM DEBUG app: Restored\n"
+
+	test.Diff(t, buf.String(), wantLog)
+}
+
+func TestConfigTimestamp(t *testing.T) {
+	hue.Enabled(false) // Force no color
+
+	fixedTime := func() time.Time {
+		return time.Date(2025, time.April, 1, 13, 34, 3, 0, time.UTC)
+	}
+
+	buf := &bytes.Buffer{}
+
+	logger := log.New(buf, log.TimeFunc(fixedTime), log.WithTimePreset(log.PresetUnix))
+	unix := logger.Config()
+
+	test.Equal(t, unix.TimePreset, log.PresetUnix)
+
+	logger.Apply(log.Config{TimeFormat: time.Kitchen, Level: log.LevelInfo, Color: log.ColorNever})
+	logger.Info("Kitchen")
+
+	logger.Apply(unix)
+	logger.Info("Unix")
+
+	plain := log.New(buf, log.TimeFunc(fixedTime), log.WithPlain())
+	saved := plain.Config()
+
+	test.True(t, saved.NoTimestamp)
+
+	plain.Apply(log.Config{TimeFormat: time.Kitchen, Level: log.LevelInfo, Color: log.ColorNever})
+	plain.Info("Timestamp")
+
+	plain.Apply(saved)
+	plain.Info("Plain")
+
+	want := "1:34PM INFO:  Kitchen\n" +
+		"1743514443 INFO:  Unix\n" +
+		"1:34PM INFO:  Timestamp\n" +
+		"INFO:  Plain\n"
+
+	test.Diff(t, buf.String(), want)
+}
@@ -0,0 +1,146 @@
+package log
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"slices"
+	"sync"
+)
+
+// contextKey is the type of keys used to store values in a [context.Context], unexported
+// so they can't collide with keys from other packages.
+type contextKey int
+
+const (
+	loggerContextKey contextKey = iota // The key for a *Logger stored with WithContext
+	levelContextKey                    // The key for a Level stored with WithLevelContext
+	attrsContextKey                    // The key for []slog.Attr accumulated with AppendCtx
+)
+
+// defaultLogger is the logger returned by [FromContext] when the context has none.
+//
+//nolint:gochecknoglobals // Created lazily, at most once
+var defaultLogger = sync.OnceValue(func() *Logger {
+	return New(os.Stderr)
+})
+
+// WithContext returns a copy of ctx carrying logger, which can be retrieved later
+// with [FromContext].
+func WithContext(ctx context.Context, logger *Logger) context.Context {
+	return context.WithValue(ctx, loggerContextKey, logger)
+}
+
+// FromContext returns the [Logger] stored in ctx by [WithContext].
+//
+// If ctx has no logger, a default logger writing to [os.Stderr] at [LevelInfo]
+// is returned so FromContext never returns nil.
+func FromContext(ctx context.Context) *Logger {
+	if logger, ok := LoggerFromContext(ctx); ok {
+		return logger
+	}
+
+	return defaultLogger()
+}
+
+// LoggerFromContext is like [FromContext] but reports whether ctx had a logger stored
+// by [WithContext] rather than falling back to the default logger, so callers can
+// choose their own fallback. If not, it returns nil and false.
+func LoggerFromContext(ctx context.Context) (*Logger, bool) {
+	logger, ok := ctx.Value(loggerContextKey).(*Logger)
+	if !ok || logger == nil {
+		return nil, false
+	}
+
+	return logger, true
+}
+
+// NewContext is like [New] but the logger's persistent attrs are seeded from any attrs
+// accumulated on ctx with [AppendCtx], so they appear on every line it writes.
+//
+//	ctx = log.AppendCtx(ctx, slog.String("service", "api"), slog.String("version", version))
+//	logger := log.NewContext(ctx, os.Stderr)
+func NewContext(ctx context.Context, w io.Writer, options ...Option) *Logger {
+	logger := New(w, options...)
+	logger.attrs = slices.Concat(attrsFromContext(ctx), logger.attrs)
+
+	return logger
+}
+
+// AppendCtx returns a copy of ctx carrying attrs in addition to any already added
+// to it by previous calls to AppendCtx.
+//
+// Context attrs are included on lines written by the context aware log methods
+// ([Logger.InfoContext] etc.) after the logger's persistent attrs and before the
+// per-call attrs, and seed the persistent attrs of loggers created with [NewContext].
+func AppendCtx(ctx context.Context, attrs ...slog.Attr) context.Context {
+	return context.WithValue(ctx, attrsContextKey, slices.Concat(attrsFromContext(ctx), attrs))
+}
+
+// attrsFromContext returns the attrs accumulated on ctx with [AppendCtx], if any.
+func attrsFromContext(ctx context.Context) []slog.Attr {
+	attrs, _ := ctx.Value(attrsContextKey).([]slog.Attr)
+	return attrs
+}
+
+// WithLevelContext returns a copy of ctx carrying a minimum log level that overrides
+// the logger's own level for the context aware log methods ([Logger.DebugContext] etc.).
+//
+// This lets a particular operation, such as a single request, be made more (or less)
+// verbose without touching the shared logger. The context level always wins over the
+// logger's configured level when present, but a [SetGlobalMinLevel] floor still applies.
+//
+//	ctx = log.WithLevelContext(ctx, log.LevelDebug)
+//	logger.DebugContext(ctx, "Shown even though logger is at info")
+func WithLevelContext(ctx context.Context, level Level) context.Context {
+	return context.WithValue(ctx, levelContextKey, level)
+}
+
+// DebugContext writes a debug level log line, using any level set on ctx
+// with [WithLevelContext] in place of the logger's own level.
+func (l *Logger) DebugContext(ctx context.Context, msg string, attrs ...slog.Attr) {
+	l.logContext(ctx, LevelDebug, msg, attrs...)
+}
+
+// InfoContext writes an info level log line, using any level set on ctx
+// with [WithLevelContext] in place of the logger's own level.
+func (l *Logger) InfoContext(ctx context.Context, msg string, attrs ...slog.Attr) {
+	l.logContext(ctx, LevelInfo, msg, attrs...)
+}
+
+// WarnContext writes a warning level log line, using any level set on ctx
+// with [WithLevelContext] in place of the logger's own level.
+func (l *Logger) WarnContext(ctx context.Context, msg string, attrs ...slog.Attr) {
+	l.logContext(ctx, LevelWarn, msg, attrs...)
+}
+
+// ErrorContext writes an error level log line, using any level set on ctx
+// with [WithLevelContext] in place of the logger's own level.
+func (l *Logger) ErrorContext(ctx context.Context, msg string, attrs ...slog.Attr) {
+	l.logContext(ctx, LevelError, msg, attrs...)
+}
+
+// logContext is like log but consults ctx for a level override.
+func (l *Logger) logContext(ctx context.Context, level Level, msg string, attrs ...slog.Attr) {
+	if !l.enabledContext(ctx, level) {
+		return
+	}
+
+	if extra := attrsFromContext(ctx); len(extra) != 0 {
+		attrs = slices.Concat(extra, attrs)
+	}
+
+	l.write(level, msg, attrs)
+}
+
+// enabledContext reports whether a log line at level would be written, using any
+// level set on ctx with [WithLevelContext] in place of the logger's own level.
+func (l *Logger) enabledContext(ctx context.Context, level Level) bool {
+	minimum := l.minLevel()
+	if override, ok := ctx.Value(levelContextKey).(Level); ok {
+		minimum = override
+	}
+
+	return !l.isDiscard && l.enabledAt(level, minimum)
+}
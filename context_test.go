@@ -0,0 +1,143 @@
+package log_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"go.followtheprocess.codes/hue"
+	"go.followtheprocess.codes/log"
+	"go.followtheprocess.codes/test"
+)
+
+func TestContext(t *testing.T) {
+	hue.Enabled(false) // Force no color
+
+	buf := &bytes.Buffer{}
+	logger := log.New(buf, log.TimeFormat(time.Kitchen), log.TimeFunc(func() time.Time {
+		return time.Date(2025, time.April, 1, 13, 34, 3, 0, time.UTC)
+	}))
+
+	ctx := log.WithContext(t.Context(), logger)
+	test.Equal(t, log.FromContext(ctx), logger, test.Context("wrong logger from context"))
+
+	test.True(t, log.FromContext(t.Context()) != nil, test.Context("expected a default logger"))
+}
+
+func TestLoggerFromContext(t *testing.T) {
+	logger := log.New(io.Discard)
+
+	got, ok := log.LoggerFromContext(log.WithContext(t.Context(), logger))
+	test.True(t, ok, test.Context("logger should be present"))
+	test.Equal(t, got, logger, test.Context("wrong logger from context"))
+
+	got, ok = log.LoggerFromContext(t.Context())
+	test.False(t, ok, test.Context("logger should be absent"))
+	test.Equal(t, got, nil, test.Context("absent logger should be nil"))
+
+	got, ok = log.LoggerFromContext(log.WithContext(t.Context(), nil))
+	test.False(t, ok, test.Context("nil logger should count as absent"))
+	test.Equal(t, got, nil)
+}
+
+func TestLevelContext(t *testing.T) {
+	hue.Enabled(false) // Force no color
+
+	fixedTime := func() time.Time {
+		return time.Date(2025, time.April, 1, 13, 34, 3, 0, time.UTC)
+	}
+
+	tests := []struct {
+		ctx   context.Context // The context to log with
+		name  string          // Name of the test case
+		want  string          // Expected output
+		level log.Level       // The level of the logger
+	}{
+		{
+			name:  "no override",
+			ctx:   t.Context(),
+			level: log.LevelInfo,
+			want:  "1:34PM INFO:  info\n1:34PM WARN:  warn\n1:34PM ERROR: error\n",
+		},
+		{
+			name:  "more verbose",
+			ctx:   log.WithLevelContext(t.Context(), log.LevelDebug),
+			level: log.LevelInfo,
+			want:  "1:34PM DEBUG: debug\n1:34PM INFO:  info\n1:34PM WARN:  warn\n1:34PM ERROR: error\n",
+		},
+		{
+			name:  "less verbose",
+			ctx:   log.WithLevelContext(t.Context(), log.LevelWarn),
+			level: log.LevelDebug,
+			want:  "1:34PM WARN:  warn\n1:34PM ERROR: error\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			logger := log.New(buf, log.WithLevel(tt.level), log.TimeFormat(time.Kitchen), log.TimeFunc(fixedTime))
+
+			logger.DebugContext(tt.ctx, "debug")
+			logger.InfoContext(tt.ctx, "info")
+			logger.WarnContext(tt.ctx, "warn")
+			logger.ErrorContext(tt.ctx, "error")
+
+			test.Diff(t, buf.String(), tt.want)
+		})
+	}
+}
+
+func TestNewContext(t *testing.T) {
+	hue.Enabled(false) // Force no color
+
+	fixedTime := func() time.Time {
+		return time.Date(2025, time.April, 1, 13, 34, 3, 0, time.UTC)
+	}
+
+	ctx := log.AppendCtx(t.Context(), slog.String("service", "api"))
+	ctx = log.AppendCtx(ctx, slog.String("version", "1.2.3"))
+
+	buf := &bytes.Buffer{}
+	logger := log.NewContext(ctx, buf, log.TimeFormat(time.Kitchen), log.TimeFunc(fixedTime))
+
+	logger.Info("Starting")
+	logger.With(slog.Int("port", 8080)).Info("Listening")
+	logger.Prefixed("db").Warn("Slow query", slog.Duration("took", time.Second))
+
+	want := "1:34PM INFO:  Starting service=api version=1.2.3\n" +
+		"1:34PM INFO:  Listening service=api version=1.2.3 port=8080\n" +
+		"1:34PM WARN db:  Slow query service=api version=1.2.3 took=1s\n"
+
+	test.Diff(t, buf.String(), want)
+
+	// Without any context attrs it's just like New
+	buf.Reset()
+	log.NewContext(t.Context(), buf, log.TimeFormat(time.Kitchen), log.TimeFunc(fixedTime)).Info("Plain")
+	test.Equal(t, buf.String(), "1:34PM INFO:  Plain\n")
+}
+
+func TestAppendCtx(t *testing.T) {
+	hue.Enabled(false) // Force no color
+
+	fixedTime := func() time.Time {
+		return time.Date(2025, time.April, 1, 13, 34, 3, 0, time.UTC)
+	}
+
+	buf := &bytes.Buffer{}
+	logger := log.New(buf, log.TimeFormat(time.Kitchen), log.TimeFunc(fixedTime)).With(slog.String("user", "tom"))
+
+	root := t.Context()
+	ctx := log.AppendCtx(root, slog.String("request", "abc"))
+
+	logger.InfoContext(ctx, "Handling", slog.Int("status", 200))
+	logger.InfoContext(root, "Unaffected")
+
+	want := "1:34PM INFO:  Handling user=tom request=abc status=200\n" +
+		"1:34PM INFO:  Unaffected user=tom\n"
+
+	test.Diff(t, buf.String(), want)
+}
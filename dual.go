@@ -0,0 +1,45 @@
+package log
+
+import (
+	"io"
+	"sync"
+)
+
+// NewDual returns a new [Logger] that writes every log line twice: as human readable
+// text to terminal and as NDJSON (see [WithJSON]) to jsonFile. This gives the common
+// setup of pretty logs for whoever's watching and machine readable ones for later:
+//
+//	file, err := os.Create("app.log")
+//	...
+//	logger := log.NewDual(os.Stderr, file)
+//
+// The options configure the logger as with [New] and so apply to both, although the
+// formatting options only affect the text. Each writer is protected by its own lock.
+// Only log lines are written to jsonFile, output from [Logger.Raw], [Logger.Separator],
+// [Logger.Banner], [Logger.Dump] and [Logger.Errors] is written to terminal alone.
+func NewDual(terminal, jsonFile io.Writer, options ...Option) *Logger {
+	logger := New(terminal, options...)
+	logger.jsonW = jsonFile
+	logger.jsonMu = &sync.Mutex{}
+
+	// Only discard if both are discarded
+	logger.isDiscard = logger.isDiscard && jsonFile == io.Discard
+
+	return logger
+}
+
+// writeJSON renders rec as JSON and writes it to the logger's JSON writer, see [NewDual].
+func (l *Logger) writeJSON(rec *record) {
+	bufp := getBuffer(l.freeList, l.bufferHint)
+	defer putBuffer(l.freeList, bufp)
+
+	buf := l.appendJSON(*bufp, rec)
+	*bufp = buf
+
+	l.jsonMu.Lock()
+	defer l.jsonMu.Unlock()
+
+	if _, err := l.jsonW.Write(buf); err != nil && l.errorHandler != nil {
+		l.errorHandler(err)
+	}
+}
@@ -0,0 +1,61 @@
+package log_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"go.followtheprocess.codes/log"
+	"go.followtheprocess.codes/test"
+)
+
+func TestDual(t *testing.T) {
+	fixedTime := func() time.Time {
+		return time.Date(2025, time.April, 1, 13, 34, 3, 0, time.UTC)
+	}
+
+	terminal := &bytes.Buffer{}
+	file := &bytes.Buffer{}
+
+	logger := log.NewDual(terminal, file, log.TimeFunc(fixedTime), log.WithColor(log.ColorAlways))
+
+	logger.Info("Starting", slog.Int("port", 8080))
+	logger.Prefixed("db").With(slog.String("host", "localhost")).Warn("Slow query")
+	logger.Debug("Filtered")
+
+	wantText := "\x1b[2m2025-04-01T13:34:03Z\x1b[0m \x1b[1;36mINFO\x1b[0m:  Starting \x1b[35mport\x1b[0m=8080\n" +
+		"\x1b[2m2025-04-01T13:34:03Z\x1b[0m \x1b[1;33mWARN\x1b[0m \x1b[1;2mdb\x1b[0m:  Slow query \x1b[35mhost\x1b[0m=localhost\n"
+
+	test.Diff(t, terminal.String(), wantText)
+
+	for line := range strings.Lines(file.String()) {
+		test.True(t, json.Valid([]byte(line)), test.Context("invalid JSON: %s", line))
+	}
+
+	wantJSON := `{"time":"2025-04-01T13:34:03Z","level":"INFO","msg":"Starting","port":8080}` + "\n" +
+		`{"time":"2025-04-01T13:34:03Z","level":"WARN","prefix":"db","msg":"Slow query","host":"localhost"}` + "\n"
+
+	test.Diff(t, file.String(), wantJSON)
+}
+
+func TestDualLazy(t *testing.T) {
+	terminal := &bytes.Buffer{}
+	file := &bytes.Buffer{}
+
+	logger := log.NewDual(terminal, file, log.WithPlain())
+
+	calls := 0
+	checksum := log.Lazy("checksum", func() any {
+		calls++
+		return "abc123"
+	})
+
+	logger.Info("Loaded", checksum)
+
+	test.Equal(t, calls, 1, test.Context("Lazy should be called once, not once per output"))
+	test.Diff(t, terminal.String(), "INFO:  Loaded checksum=abc123\n")
+	test.True(t, strings.Contains(file.String(), `"checksum":"abc123"`))
+}
@@ -0,0 +1,267 @@
+package log
+
+import (
+	"cmp"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"slices"
+	"strconv"
+	"unicode/utf8"
+)
+
+const (
+	// dumpKey is the key [Logger.Dump] uses for the dumped value in JSON output.
+	dumpKey = "value"
+
+	// dumpIndent is the indent for each nesting level of a dumped value.
+	dumpIndent = "    "
+
+	// maxDumpDepth is the deepest nesting level of a dumped value that will be
+	// expanded, anything deeper is shown as "...".
+	maxDumpDepth = 8
+)
+
+// Dump writes a debug level log line with the given message, followed by v pretty
+// printed as an indented block beneath it with one field, map entry or element per line.
+//
+//	logger.Dump("Loaded config", cfg)
+//
+//	2025-04-01T13:34:03Z DEBUG: Loaded config
+//	    Name:  "my app"
+//	    Port:  8080
+//	    Owner:
+//	        Name:  tom
+//	        Email: tom@example.com
+//
+// Keys at each level are aligned and styled like attr keys. Unexported struct fields are
+// skipped, map entries are sorted by key and values that implement [fmt.Stringer] or
+// error (e.g. [time.Time]) are shown inline rather than expanded. Nesting is expanded up
+// to 8 levels deep and cycles are shown as <cycle>.
+//
+// It is intended for occasional deep inspection while debugging. With [WithJSON], or
+// for a logger created with [NewChannel], v is instead added to the record under the
+// key "value".
+func (l *Logger) Dump(msg string, v any) {
+	if l.isDiscard || !l.enabled(LevelDebug) {
+		return
+	}
+
+	if l.json || l.records != nil {
+		l.write(LevelDebug, msg, []slog.Attr{slog.Any(dumpKey, v)})
+		return
+	}
+
+	if !l.claimOnce() {
+		return
+	}
+
+	var extra [extraAttrs]slog.Attr
+
+	rec := l.newRecord(l.timeFunc(), LevelDebug, msg, nil, extra[:0])
+
+	d := dumper{logger: l, seen: make(map[visit]bool)}
+
+	buf := l.render(make([]byte, 0, bufferSize), &rec)
+	buf = d.appendTop(buf, reflect.ValueOf(v))
+
+	l.emit(LevelDebug, buf)
+}
+
+// visit identifies a pointer, map or slice on the path currently being dumped.
+type visit struct {
+	typ  reflect.Type
+	addr uintptr
+}
+
+// dumpEntry is a single key and value in a dumped block.
+type dumpEntry struct {
+	value reflect.Value
+	key   string
+}
+
+// dumper renders values for [Logger.Dump].
+type dumper struct {
+	logger *Logger
+	seen   map[visit]bool // The values on the current path, for cycle detection
+}
+
+// appendTop appends the top level value passed to [Logger.Dump].
+func (d *dumper) appendTop(dst []byte, val reflect.Value) []byte {
+	val, ptr := indirect(val)
+
+	entries := d.entries(val)
+	if len(entries) == 0 {
+		dst = append(dst, dumpIndent...)
+		dst = d.appendScalar(dst, val)
+
+		return d.logger.appendEOL(dst)
+	}
+
+	if id, ok := identity(val, ptr); ok {
+		d.seen[id] = true
+	}
+
+	return d.appendBlock(dst, entries, 1)
+}
+
+// appendBlock appends entries one per line at the given depth, with keys aligned.
+func (d *dumper) appendBlock(dst []byte, entries []dumpEntry, depth int) []byte {
+	width := 0
+	for _, entry := range entries {
+		width = max(width, utf8.RuneCountInString(entry.key))
+	}
+
+	for _, entry := range entries {
+		for range depth {
+			dst = append(dst, dumpIndent...)
+		}
+
+		dst = appendStyled(dst, d.logger.colorMode(), keyStyle, entry.key)
+		dst = append(dst, ':')
+
+		val, ptr := indirect(entry.value)
+
+		pad := width - utf8.RuneCountInString(entry.key) + 1
+
+		nested := d.entries(val)
+		if len(nested) == 0 {
+			dst = appendSpaces(dst, pad)
+			dst = d.appendScalar(dst, val)
+			dst = d.logger.appendEOL(dst)
+
+			continue
+		}
+
+		dst = d.appendNested(dst, val, ptr, nested, depth+1, pad)
+	}
+
+	return dst
+}
+
+// appendNested appends a composite value as a block on the lines following its key,
+// unless it's too deep or already being dumped further up the path, in which case
+// a marker is shown inline instead, after pad spaces.
+func (d *dumper) appendNested(dst []byte, val reflect.Value, ptr uintptr, entries []dumpEntry, depth, pad int) []byte {
+	if depth > maxDumpDepth {
+		return d.logger.appendEOL(append(appendSpaces(dst, pad), "..."...))
+	}
+
+	if id, ok := identity(val, ptr); ok {
+		if d.seen[id] {
+			return d.logger.appendEOL(append(appendSpaces(dst, pad), "<cycle>"...))
+		}
+
+		d.seen[id] = true
+		defer delete(d.seen, id)
+	}
+
+	dst = d.logger.appendEOL(dst)
+
+	return d.appendBlock(dst, entries, depth)
+}
+
+// appendScalar appends a value shown inline, formatted as attr values are.
+func (d *dumper) appendScalar(dst []byte, val reflect.Value) []byte {
+	if !val.IsValid() {
+		return append(dst, "<nil>"...)
+	}
+
+	return d.logger.appendValue(dst, slog.AnyValue(val.Interface()))
+}
+
+// entries returns the entries of val if it should be expanded into a block: a struct
+// with exported fields or a non-empty map, slice or array. Otherwise it returns nil
+// and val is shown inline.
+func (d *dumper) entries(val reflect.Value) []dumpEntry {
+	if !val.IsValid() || inline(val) {
+		return nil
+	}
+
+	var entries []dumpEntry
+
+	switch val.Kind() {
+	case reflect.Struct:
+		typ := val.Type()
+		for i := range typ.NumField() {
+			if field := typ.Field(i); field.IsExported() {
+				entries = append(entries, dumpEntry{key: field.Name, value: val.Field(i)})
+			}
+		}
+	case reflect.Map:
+		iter := val.MapRange()
+		for iter.Next() {
+			entries = append(entries, dumpEntry{key: fmt.Sprint(iter.Key().Interface()), value: iter.Value()})
+		}
+
+		slices.SortFunc(entries, func(a, b dumpEntry) int {
+			return cmp.Compare(a.key, b.key)
+		})
+	case reflect.Slice, reflect.Array:
+		if val.Type().Elem().Kind() == reflect.Uint8 {
+			// Raw bytes, not a list
+			return nil
+		}
+
+		for i := range val.Len() {
+			entries = append(entries, dumpEntry{key: strconv.Itoa(i), value: val.Index(i)})
+		}
+	default:
+		return nil
+	}
+
+	return entries
+}
+
+// inline reports whether val knows how to display itself and so shouldn't be
+// expanded, e.g. a [time.Time].
+func inline(val reflect.Value) bool {
+	if !val.CanInterface() {
+		return false
+	}
+
+	switch val.Interface().(type) {
+	case fmt.Stringer, error:
+		return true
+	default:
+		return false
+	}
+}
+
+// indirect follows pointers and interfaces from val to the underlying value,
+// returning it and the address of the last pointer followed, if any. A nil pointer
+// or interface returns the zero [reflect.Value].
+func indirect(val reflect.Value) (reflect.Value, uintptr) {
+	var ptr uintptr
+
+	for val.Kind() == reflect.Pointer || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			return reflect.Value{}, 0
+		}
+
+		if val.Kind() == reflect.Pointer {
+			if inline(val) {
+				// e.g. a type with a String method on the pointer receiver
+				return val, 0
+			}
+
+			ptr = val.Pointer()
+		}
+
+		val = val.Elem()
+	}
+
+	return val, ptr
+}
+
+// identity returns the identity of val for cycle detection, if it has one.
+func identity(val reflect.Value, ptr uintptr) (visit, bool) {
+	switch {
+	case ptr != 0:
+		return visit{typ: val.Type(), addr: ptr}, true
+	case val.Kind() == reflect.Map, val.Kind() == reflect.Slice:
+		return visit{typ: val.Type(), addr: val.Pointer()}, true
+	default:
+		return visit{}, false
+	}
+}
@@ -0,0 +1,113 @@
+package log_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"go.followtheprocess.codes/hue"
+	"go.followtheprocess.codes/log"
+	"go.followtheprocess.codes/test"
+)
+
+type owner struct {
+	Name  string
+	Email string
+}
+
+type config struct {
+	Labels  map[string]string
+	Owner   *owner
+	Created time.Time
+	Name    string
+	secret  string
+	Tags    []string
+	Port    int
+}
+
+type node struct {
+	Next  *node
+	Value int
+}
+
+func TestDump(t *testing.T) {
+	fixedTime := func() time.Time {
+		return time.Date(2025, time.April, 1, 13, 34, 3, 0, time.UTC)
+	}
+
+	cyclic := &node{Value: 1}
+	cyclic.Next = &node{Value: 2, Next: cyclic}
+
+	tests := []struct {
+		value any    // The value to dump
+		name  string // Name of the test case
+		want  string // Expected output
+	}{
+		{
+			name: "nested struct",
+			value: config{
+				Name:    "my app",
+				Port:    8080,
+				Owner:   &owner{Name: "tom", Email: "tom@example.com"},
+				Tags:    []string{"cli", "logs"},
+				Labels:  map[string]string{"team": "platform", "env": "prod"},
+				Created: fixedTime(),
+				secret:  "hidden",
+			},
+			want: "1:34PM DEBUG: Config\n" +
+				"    Labels:\n" +
+				"        env:  prod\n" +
+				"        team: platform\n" +
+				"    Owner:\n" +
+				"        Name:  tom\n" +
+				"        Email: tom@example.com\n" +
+				"    Created: \"2025-04-01 13:34:03 +0000 UTC\"\n" +
+				"    Name:    \"my app\"\n" +
+				"    Tags:\n" +
+				"        0: cli\n" +
+				"        1: logs\n" +
+				"    Port:    8080\n",
+		},
+		{
+			name:  "cycle",
+			value: cyclic,
+			want: "1:34PM DEBUG: Config\n" +
+				"    Next:\n" +
+				"        Next:  <cycle>\n" +
+				"        Value: 2\n" +
+				"    Value: 1\n",
+		},
+		{
+			name:  "scalar",
+			value: 42,
+			want:  "1:34PM DEBUG: Config\n    42\n",
+		},
+		{
+			name:  "nil",
+			value: nil,
+			want:  "1:34PM DEBUG: Config\n    <nil>\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hue.Enabled(false)
+
+			buf := &bytes.Buffer{}
+
+			logger := log.New(buf, log.TimeFunc(fixedTime), log.TimeFormat(time.Kitchen), log.WithLevel(log.LevelDebug))
+
+			logger.Dump("Config", tt.value)
+
+			test.Diff(t, buf.String(), tt.want)
+		})
+	}
+
+	t.Run("filtered", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+
+		log.New(buf).Dump("Config", config{})
+
+		test.Equal(t, buf.Len(), 0)
+	})
+}
@@ -0,0 +1,139 @@
+package log
+
+import (
+	"log/slog"
+	"strconv"
+
+	"go.followtheprocess.codes/hue"
+)
+
+const (
+	// errKey is the key used by [Err].
+	errKey = "err"
+
+	// errorsKey is the key [Logger.Errors] uses for the list of errors in JSON output.
+	errorsKey = "errors"
+
+	// errorIndent is the indent of each error listed by [Logger.Errors].
+	errorIndent = "    "
+
+	// maxJoinedErrors is the maximum number of errors from a multi-error that are
+	// rendered as their own attr, any beyond this are summarised as a count.
+	maxJoinedErrors = 10
+)
+
+// Err returns a [slog.Attr] for err under the key "err".
+//
+// If err wraps multiple errors, as created by [errors.Join] or [fmt.Errorf] with
+// more than one %w verb, each one is rendered as its own numbered attr rather than a
+// single multi-line string:
+//
+//	logger.Error("Build failed", log.Err(errors.Join(errOne, errTwo)))
+//	// ... ERROR: Build failed err.0="first error" err.1="second error"
+//
+// Up to 10 errors are shown this way, with any more summarised as a count under
+// err.more. Any other attr whose value is a multi-error is expanded in the same way.
+func Err(err error) slog.Attr {
+	return slog.Any(errKey, err)
+}
+
+// errorMarkerStyle is the style of the numbers marking each error listed by [Logger.Errors].
+const errorMarkerStyle = hue.Red
+
+// Errors writes an error level log line with the given message, followed by each of
+// errs as an indented, numbered line beneath it, e.g. for a batch of validation failures:
+//
+//	logger.Errors("Invalid config", errs)
+//
+//	2025-04-01T13:34:03Z ERROR: Invalid config
+//	    1. name: must not be empty
+//	    2. port: must be between 1 and 65535
+//
+// Nil errors are shown as <nil>. If errs is empty, nothing is written. With [WithJSON],
+// the error messages are instead added to the record as a list under the key "errors".
+func (l *Logger) Errors(msg string, errs []error) {
+	if len(errs) == 0 || l.isDiscard || !l.enabled(LevelError) {
+		return
+	}
+
+	if l.json || l.records != nil {
+		texts := make([]string, 0, len(errs))
+		for _, err := range errs {
+			texts = append(texts, errorText(err))
+		}
+
+		l.write(LevelError, msg, []slog.Attr{slog.Any(errorsKey, texts)})
+
+		return
+	}
+
+	if !l.claimOnce() {
+		return
+	}
+
+	var extra [extraAttrs]slog.Attr
+
+	rec := l.newRecord(l.timeFunc(), LevelError, msg, nil, extra[:0])
+
+	buf := l.render(make([]byte, 0, bufferSize), &rec)
+
+	// Right align the numbers so the errors line up
+	width := len(strconv.Itoa(len(errs)))
+
+	for i, err := range errs {
+		marker := strconv.Itoa(i+1) + "."
+
+		buf = append(buf, errorIndent...)
+		buf = appendSpaces(buf, width+1-len(marker))
+		buf = appendStyled(buf, l.colorMode(), errorMarkerStyle, marker)
+		buf = append(buf, ' ')
+		buf = append(buf, errorText(err)...)
+		buf = l.appendEOL(buf)
+	}
+
+	l.emit(LevelError, buf)
+}
+
+// joinedErrors returns the errors wrapped by v if it is a multi-error, that
+// is an error with an Unwrap() []error method wrapping at least one error.
+func joinedErrors(v slog.Value) ([]error, bool) {
+	if v.Kind() != slog.KindAny {
+		return nil, false
+	}
+
+	multi, ok := v.Any().(interface{ Unwrap() []error })
+	if !ok {
+		return nil, false
+	}
+
+	errs := multi.Unwrap()
+
+	return errs, len(errs) > 0
+}
+
+// expandErrors returns one attr per error in errs with keys of the form key.0, key.1
+// and so on, up to [maxJoinedErrors]. If there are more errors than that, a final
+// key.more attr records how many were left out.
+func expandErrors(key string, errs []error) []slog.Attr {
+	n := min(len(errs), maxJoinedErrors)
+
+	attrs := make([]slog.Attr, 0, n+1)
+	for i, err := range errs[:n] {
+		attrs = append(attrs, slog.String(key+"."+strconv.Itoa(i), errorText(err)))
+	}
+
+	if rest := len(errs) - n; rest > 0 {
+		attrs = append(attrs, slog.Int(key+".more", rest))
+	}
+
+	return attrs
+}
+
+// errorText returns err.Error(), tolerating a nil error.
+func errorText(err error) string {
+	if err == nil {
+		return "<nil>"
+	}
+
+	return err.Error()
+}
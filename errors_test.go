@@ -0,0 +1,155 @@
+package log_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+
+	"go.followtheprocess.codes/hue"
+	"go.followtheprocess.codes/log"
+	"go.followtheprocess.codes/test"
+)
+
+func TestErr(t *testing.T) {
+	hue.Enabled(false) // Force no color
+
+	many := make([]error, 0, 12)
+	for i := range 12 {
+		many = append(many, fmt.Errorf("error %d", i))
+	}
+
+	tests := []struct {
+		attr slog.Attr // The attr to log
+		name string    // Name of the test case
+		want string    // Expected log line
+		json bool      // Log as JSON rather than text
+	}{
+		{
+			name: "single",
+			attr: log.Err(errors.New("file not found")),
+			want: "1:34PM ERROR: Failed err=\"file not found\"\n",
+		},
+		{
+			name: "joined",
+			attr: log.Err(errors.Join(errors.New("one"), errors.New("two"), errors.New("three things"))),
+			want: "1:34PM ERROR: Failed err.0=one err.1=two err.2=\"three things\"\n",
+		},
+		{
+			name: "joined other key",
+			attr: slog.Any("cause", errors.Join(errors.New("one"), errors.New("two"))),
+			want: "1:34PM ERROR: Failed cause.0=one cause.1=two\n",
+		},
+		{
+			name: "errorf multiple wraps",
+			attr: log.Err(fmt.Errorf("%w and %w", errors.New("one"), errors.New("two"))),
+			want: "1:34PM ERROR: Failed err.0=one err.1=two\n",
+		},
+		{
+			name: "capped",
+			attr: log.Err(errors.Join(many...)),
+			want: "1:34PM ERROR: Failed err.0=\"error 0\" err.1=\"error 1\" err.2=\"error 2\" err.3=\"error 3\" " +
+				"err.4=\"error 4\" err.5=\"error 5\" err.6=\"error 6\" err.7=\"error 7\" err.8=\"error 8\" " +
+				"err.9=\"error 9\" err.more=2\n",
+		},
+		{
+			name: "joined json",
+			attr: log.Err(errors.Join(errors.New("one"), errors.New("two"), errors.New("three"))),
+			json: true,
+			want: `{"time":"1:34PM","level":"ERROR","msg":"Failed","err.0":"one","err.1":"two","err.2":"three"}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+
+			fixedTime := func() time.Time {
+				return time.Date(2025, time.April, 1, 13, 34, 3, 0, time.UTC)
+			}
+
+			options := []log.Option{log.TimeFunc(fixedTime), log.TimeFormat(time.Kitchen)}
+			if tt.json {
+				options = append(options, log.WithJSON())
+			}
+
+			logger := log.New(buf, options...)
+
+			logger.Error("Failed", tt.attr)
+
+			test.Diff(t, buf.String(), tt.want)
+		})
+	}
+}
+
+func TestErrors(t *testing.T) {
+	fixedTime := func() time.Time {
+		return time.Date(2025, time.April, 1, 13, 34, 3, 0, time.UTC)
+	}
+
+	many := make([]error, 0, 10)
+	for i := range 10 {
+		many = append(many, fmt.Errorf("error %d", i+1))
+	}
+
+	tests := []struct {
+		name    string       // Name of the test case
+		want    string       // Expected log output
+		errs    []error      // The errors to log
+		options []log.Option // Options to construct the logger with
+	}{
+		{
+			name: "text",
+			errs: []error{errors.New("name: must not be empty"), nil, errors.New("port: out of range")},
+			want: "2025-04-01T13:34:03Z ERROR: Invalid config\n" +
+				"    1. name: must not be empty\n" +
+				"    2. <nil>\n" +
+				"    3. port: out of range\n",
+		},
+		{
+			name: "aligned",
+			errs: many,
+			want: "2025-04-01T13:34:03Z ERROR: Invalid config\n" +
+				"     1. error 1\n     2. error 2\n     3. error 3\n     4. error 4\n     5. error 5\n" +
+				"     6. error 6\n     7. error 7\n     8. error 8\n     9. error 9\n    10. error 10\n",
+		},
+		{
+			name:    "colour",
+			errs:    []error{errors.New("bad")},
+			options: []log.Option{log.WithPlain(), log.WithColor(log.ColorAlways)},
+			want:    "\x1b[1;31mERROR\x1b[0m: Invalid config\n    \x1b[31m1.\x1b[0m bad\n",
+		},
+		{
+			name:    "json",
+			errs:    []error{errors.New("bad"), errors.New("worse")},
+			options: []log.Option{log.WithJSON()},
+			want:    `{"time":"2025-04-01T13:34:03Z","level":"ERROR","msg":"Invalid config","errors":["bad","worse"]}` + "\n",
+		},
+		{
+			name: "empty",
+			errs: nil,
+			want: "",
+		},
+		{
+			name:    "filtered",
+			errs:    []error{errors.New("bad")},
+			options: []log.Option{log.WithLevel(log.Level(100))},
+			want:    "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hue.Enabled(false) // Force no color
+
+			buf := &bytes.Buffer{}
+
+			logger := log.New(buf, append([]log.Option{log.TimeFunc(fixedTime)}, tt.options...)...)
+			logger.Errors("Invalid config", tt.errs)
+
+			test.Diff(t, buf.String(), tt.want)
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package log
+
+import (
+	"log/slog"
+	"slices"
+)
+
+// eventKey is the reserved key for the name of an event logged with [Logger.Event].
+const eventKey = "event"
+
+// Event writes an info level log line recording a named event, for analytics style
+// logging where lines are later aggregated by event name:
+//
+//	logger.Event("user.signup", slog.String("plan", "pro"))
+//	// ... INFO:  event=user.signup plan=pro
+//
+// The name is logged under the reserved key "event", ahead of the attrs passed to Event,
+// and the free text message is left empty so the attrs take its place. With [WithJSON] it's likewise
+// an "event" key. Sticking to a consistent naming scheme, such as "noun.verb", makes
+// events easier to query.
+func (l *Logger) Event(name string, attrs ...slog.Attr) {
+	if l.isDiscard || !l.enabled(LevelInfo) {
+		return
+	}
+
+	l.write(LevelInfo, "", slices.Concat([]slog.Attr{slog.String(eventKey, name)}, attrs))
+}
@@ -0,0 +1,59 @@
+package log_test
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+	"time"
+
+	"go.followtheprocess.codes/hue"
+	"go.followtheprocess.codes/log"
+	"go.followtheprocess.codes/test"
+)
+
+func TestEvent(t *testing.T) {
+	hue.Enabled(false) // Force no color
+
+	fixedTime := func() time.Time {
+		return time.Date(2025, time.April, 1, 13, 34, 3, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name       string       // Name of the test case
+		want       string       // Expected log output
+		options    []log.Option // Options to construct the logger with
+		persistent []slog.Attr  // Persistent attrs to add with With
+	}{
+		{
+			name: "text",
+			want: "2025-04-01T13:34:03Z INFO:  event=user.signup plan=pro\n",
+		},
+		{
+			name:       "persistent",
+			options:    []log.Option{log.WithAttrSections()},
+			persistent: []slog.Attr{slog.String("region", "eu")},
+			want:       "2025-04-01T13:34:03Z INFO:  [region=eu] event=user.signup plan=pro\n",
+		},
+		{
+			name:    "json",
+			options: []log.Option{log.WithJSON()},
+			want:    `{"time":"2025-04-01T13:34:03Z","level":"INFO","msg":"","event":"user.signup","plan":"pro"}` + "\n",
+		},
+		{
+			name:    "disabled",
+			options: []log.Option{log.WithLevel(log.LevelWarn)},
+			want:    "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			logger := log.New(buf, append([]log.Option{log.TimeFunc(fixedTime)}, tt.options...)...).With(tt.persistent...)
+
+			logger.Event("user.signup", slog.String("plan", "pro"))
+
+			test.Diff(t, buf.String(), tt.want)
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package log
+
+import (
+	"log/slog"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// Keys used by [Command].
+const (
+	programKey = "program"
+	argsKey    = "args"
+	dirKey     = "dir"
+)
+
+// sensitiveFlags are words which, if they appear in the name of a command line flag,
+// mean its value is redacted by [Command].
+//
+//nolint:gochecknoglobals // Constant lookup table
+var sensitiveFlags = []string{"password", "passwd", "secret", "token", "apikey", "api-key", "api_key"}
+
+// Command returns the conventional attrs for running a command: the path of the program,
+// its arguments and the working directory (if set), for use with the log methods:
+//
+//	cmd := exec.Command("go", "build", "-o", "bin/my app", "./...")
+//	logger.Debug("Running", log.Command(cmd)...)
+//	// ... DEBUG: Running program=/usr/local/go/bin/go args="build -o \"bin/my app\" ./..."
+//
+// The arguments are joined with spaces, each quoted if it contains whitespace or is empty.
+// The values of flags whose names suggest they hold credentials, such as --password or
+// --api-token, are redacted, whether given as "--token=value" or "--token value". A nil
+// command returns nil.
+func Command(cmd *exec.Cmd) []slog.Attr {
+	if cmd == nil {
+		return nil
+	}
+
+	attrs := []slog.Attr{slog.String(programKey, cmd.Path)}
+
+	// Args[0] is the program name, which we already have
+	if len(cmd.Args) > 1 {
+		attrs = append(attrs, slog.String(argsKey, joinArgs(cmd.Args[1:])))
+	}
+
+	if cmd.Dir != "" {
+		attrs = append(attrs, slog.String(dirKey, cmd.Dir))
+	}
+
+	return attrs
+}
+
+// joinArgs joins args with spaces, quoting any that need it and redacting the values
+// of sensitive flags.
+func joinArgs(args []string) string {
+	builder := &strings.Builder{}
+
+	redactNext := false
+
+	for i, arg := range args {
+		if i != 0 {
+			builder.WriteByte(' ')
+		}
+
+		switch {
+		case redactNext && !strings.HasPrefix(arg, "-"):
+			arg = redacted
+			redactNext = false
+		case strings.HasPrefix(arg, "-"):
+			// A flag straight after a sensitive one means that one was a boolean
+			// e.g. --no-token-cache, so there's no value to redact
+			redactNext = false
+			name, _, hasValue := strings.Cut(arg, "=")
+			if sensitiveFlag(name) {
+				if hasValue {
+					arg = name + "=" + redacted
+				} else {
+					redactNext = true
+				}
+			}
+		}
+
+		if arg == "" || needsQuotes(arg) {
+			arg = strconv.Quote(arg)
+		}
+
+		builder.WriteString(arg)
+	}
+
+	return builder.String()
+}
+
+// sensitiveFlag reports whether the flag name (including its leading dashes) suggests
+// its value is a credential.
+func sensitiveFlag(name string) bool {
+	name = strings.ToLower(name)
+	for _, word := range sensitiveFlags {
+		if strings.Contains(name, word) {
+			return true
+		}
+	}
+
+	return false
+}
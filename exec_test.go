@@ -0,0 +1,78 @@
+package log_test
+
+import (
+	"os/exec"
+	"testing"
+
+	"go.followtheprocess.codes/log"
+	"go.followtheprocess.codes/test"
+)
+
+func TestCommand(t *testing.T) {
+	tests := []struct {
+		cmd  *exec.Cmd // The command to get attrs for
+		name string    // Name of the test case
+		want string    // Expected attrs, formatted as key=value pairs
+	}{
+		{
+			name: "no args",
+			cmd:  &exec.Cmd{Path: "/usr/bin/ls", Args: []string{"ls"}},
+			want: "program=/usr/bin/ls",
+		},
+		{
+			name: "spaces",
+			cmd: &exec.Cmd{
+				Path: "/usr/local/go/bin/go",
+				Args: []string{"go", "build", "-o", "bin/my app", "", "./..."},
+				Dir:  "/src",
+			},
+			want: `program=/usr/local/go/bin/go args=build -o "bin/my app" "" ./... dir=/src`,
+		},
+		{
+			name: "redacted",
+			cmd: &exec.Cmd{
+				Path: "/usr/bin/deploy",
+				Args: []string{"deploy", "--password", "hunter2", "--API-TOKEN=abc", "--user", "tom", "--secret"},
+			},
+			want: "program=/usr/bin/deploy args=--password REDACTED --API-TOKEN=REDACTED --user tom --secret",
+		},
+		{
+			name: "boolean sensitive flag",
+			cmd: &exec.Cmd{
+				Path: "/usr/bin/deploy",
+				Args: []string{"deploy", "--token", "--no-token-cache", "--verbose", "prod"},
+			},
+			want: "program=/usr/bin/deploy args=--token --no-token-cache --verbose prod",
+		},
+		{
+			name: "nil",
+			cmd:  nil,
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got string
+
+			for i, attr := range log.Command(tt.cmd) {
+				if i != 0 {
+					got += " "
+				}
+
+				got += attr.String()
+			}
+
+			test.Equal(t, got, tt.want)
+		})
+	}
+
+	t.Run("exec.Command", func(t *testing.T) {
+		cmd := exec.Command("go", "version")
+
+		attrs := log.Command(cmd)
+		test.Equal(t, len(attrs), 2)
+		test.Equal(t, attrs[0].Value.String(), cmd.Path)
+		test.Equal(t, attrs[1].Value.String(), "version")
+	})
+}
@@ -0,0 +1,52 @@
+package log
+
+import (
+	"io"
+	"sync"
+)
+
+// FakeTerminal makes the package treat w as a terminal for the rest of the test.
+func FakeTerminal(tb interface{ Cleanup(fn func()) }, w io.Writer) {
+	original := isTerminal
+	isTerminal = func(candidate io.Writer) bool {
+		return candidate == w || original(candidate)
+	}
+
+	tb.Cleanup(func() { isTerminal = original })
+}
+
+// FakeTerminalWidth makes the package treat w as a terminal of the given width
+// for the rest of the test.
+func FakeTerminalWidth(tb interface{ Cleanup(fn func()) }, w io.Writer, width int) {
+	FakeTerminal(tb, w)
+
+	original := terminalWidth
+	terminalWidth = func(candidate io.Writer) int {
+		if candidate == w {
+			return width
+		}
+
+		return original(candidate)
+	}
+
+	tb.Cleanup(func() { terminalWidth = original })
+}
+
+// EmptyBufferPool empties the pool of line buffers, so the next line is rendered into
+// a fresh buffer rather than one already grown by an earlier line.
+func EmptyBufferPool() {
+	bufPool = sync.Pool{New: bufPool.New}
+}
+
+// ResetOnce forgets every key passed to [Logger.Once] so far, so tests using Once
+// can run more than once in the same process.
+func ResetOnce(tb interface{ Cleanup(fn func()) }) {
+	onceKeys.Clear()
+	tb.Cleanup(onceKeys.Clear)
+}
+
+// FreeListLen returns the number of buffers currently in the logger's free list,
+// see [WithBufferStrategy].
+func FreeListLen(l *Logger) int {
+	return len(l.freeList)
+}
@@ -10,10 +10,10 @@ ignore (
 require (
 	go.followtheprocess.codes/hue v1.2.0
 	go.followtheprocess.codes/test v1.4.0
+	golang.org/x/term v0.44.0
 )
 
 require (
 	go.followtheprocess.codes/diff v0.2.0 // indirect
 	golang.org/x/sys v0.46.0 // indirect
-	golang.org/x/term v0.44.0 // indirect
 )
@@ -0,0 +1,90 @@
+package log
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+	"sync"
+)
+
+// goroutineKey is the key used for the goroutine ID attr added by [WithGoroutineID].
+const goroutineKey = "goroutine"
+
+// bound holds the loggers bound to goroutines with [Bind], keyed by goroutine ID.
+//
+//nolint:gochecknoglobals // Process wide by design
+var bound sync.Map
+
+// Bind binds logger to the calling goroutine, so that [Current] called from the same
+// goroutine returns it, and returns a function that removes the binding again. This lets
+// helper functions log without having a logger threaded through to them:
+//
+//	func handle(req Request) {
+//		defer log.Bind(logger.With(slog.String("id", req.ID)))()
+//		process(req) // process can call log.Current()
+//	}
+//
+// Go deliberately has no goroutine local storage, so this should be used sparingly and
+// passing a logger explicitly or in a [context.Context] (see [WithContext]) is preferred.
+// In particular:
+//
+//   - The binding is not inherited by goroutines started from the bound one.
+//   - Binding relies on parsing the goroutine's ID from its stack trace, which is
+//     relatively expensive, as is each call to Current.
+//   - The binding is held until the returned function is called, even if the goroutine
+//     exits, and goroutine IDs may be reused, so the function must always be called.
+//
+// Bindings may be nested, the returned function restores whichever logger was bound
+// before, if any.
+func Bind(logger *Logger) func() {
+	id := goroutineID()
+	previous, hadPrevious := bound.Swap(id, logger)
+
+	return func() {
+		if hadPrevious {
+			bound.Store(id, previous)
+			return
+		}
+
+		bound.Delete(id)
+	}
+}
+
+// Current returns the [Logger] bound to the calling goroutine with [Bind].
+//
+// If there is none, the same default logger as [FromContext] is returned, writing to
+// [os.Stderr] at [LevelInfo], so Current never returns nil.
+func Current() *Logger {
+	if logger, ok := bound.Load(goroutineID()); ok {
+		return logger.(*Logger) //nolint:forcetypeassert // Only Bind stores values
+	}
+
+	return defaultLogger()
+}
+
+// goroutineID returns the ID of the calling goroutine, or 0 if it can't be determined.
+//
+// The runtime deliberately doesn't expose this so it's parsed out of the first line of
+// the goroutine's stack trace, which looks like "goroutine 123 [running]:". This is
+// relatively expensive and intended only for debugging.
+func goroutineID() uint64 {
+	// Comfortably big enough for the first line
+	const size = 64
+
+	var buf [size]byte
+
+	line := buf[:runtime.Stack(buf[:], false)]
+	line = bytes.TrimPrefix(line, []byte("goroutine "))
+
+	end := bytes.IndexByte(line, ' ')
+	if end < 0 {
+		return 0
+	}
+
+	id, err := strconv.ParseUint(string(line[:end]), base10, 64)
+	if err != nil {
+		return 0
+	}
+
+	return id
+}
@@ -0,0 +1,118 @@
+package log
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Handler returns a [slog.Handler] that writes records through the logger, so code
+// using [log/slog] gets the same output (format, colour, prefix, persistent attrs and
+// so on) as code using the logger directly.
+//
+//	slogger := slog.New(logger.Handler())
+//
+// The slog levels map directly onto this package's levels. Records are timestamped by
+// the logger's [TimeFunc] rather than by slog, and levels set on a context with
+// [WithLevelContext] and attrs added to it with [AppendCtx] are respected. Attrs within
+// a group opened with [slog.Logger.WithGroup], or in a [slog.Group], have their keys
+// prefixed with the group name and the delimiter set by [WithDelimiter] e.g.
+// "request.method". Records with a zero time are written without one.
+func (l *Logger) Handler() slog.Handler {
+	return handler{logger: l}
+}
+
+// SetAsSlogDefault makes the logger the default for the [log/slog] package, so that
+// top level calls like [slog.Info] are written by it. It is shorthand for:
+//
+//	slog.SetDefault(slog.New(logger.Handler()))
+//
+// This mutates global state in the slog package (and by extension the standard library
+// log package, whose output slog then redirects), affecting every user of it in the
+// program, so it is intended to be called once, early in main.
+func (l *Logger) SetAsSlogDefault() {
+	slog.SetDefault(slog.New(l.Handler()))
+}
+
+// handler is the [slog.Handler] returned by [Logger.Handler].
+type handler struct {
+	logger *Logger
+	group  string // The key prefix from any open groups, each followed by the delimiter e.g. "request.", "" if none
+}
+
+// Enabled implements [slog.Handler].
+func (h handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.logger.enabledContext(ctx, Level(level))
+}
+
+// Handle implements [slog.Handler].
+func (h handler) Handle(ctx context.Context, record slog.Record) error {
+	// Context attrs aren't part of any group opened on the handler
+	fromContext := attrsFromContext(ctx)
+
+	attrs := make([]slog.Attr, 0, len(fromContext)+record.NumAttrs())
+	attrs = append(attrs, fromContext...)
+
+	record.Attrs(func(attr slog.Attr) bool {
+		attrs = h.appendAttr(attrs, h.group, attr)
+		return true
+	})
+
+	// The record's own time is only used to tell whether it has one
+	t := h.logger.timeFunc()
+	if record.Time.IsZero() {
+		t = time.Time{}
+	}
+
+	h.logger.writeAt(t, Level(record.Level), record.Message, attrs)
+
+	return nil
+}
+
+// WithAttrs implements [slog.Handler].
+func (h handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	grouped := make([]slog.Attr, 0, len(attrs))
+	for _, attr := range attrs {
+		grouped = h.appendAttr(grouped, h.group, attr)
+	}
+
+	return handler{logger: h.logger.With(grouped...), group: h.group}
+}
+
+// WithGroup implements [slog.Handler].
+func (h handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	return handler{logger: h.logger, group: h.group + name + h.logger.delimiter}
+}
+
+// appendAttr appends attr to attrs with its key prefixed by group, following the
+// [slog.Handler] rules: empty attrs and groups are dropped, and the members of a group
+// are flattened into attrs of their own, keyed by the group name and the delimiter, or
+// inlined if the group has no key.
+func (h handler) appendAttr(attrs []slog.Attr, group string, attr slog.Attr) []slog.Attr {
+	if attr.Equal(slog.Attr{}) {
+		return attrs
+	}
+
+	// A LogValuer may resolve to a group, it's resolved just the once either way
+	attr.Value = attr.Value.Resolve()
+
+	if attr.Value.Kind() == slog.KindGroup {
+		if attr.Key != "" {
+			group += attr.Key + h.logger.delimiter
+		}
+
+		for _, member := range attr.Value.Group() {
+			attrs = h.appendAttr(attrs, group, member)
+		}
+
+		return attrs
+	}
+
+	attr.Key = group + attr.Key
+
+	return append(attrs, attr)
+}
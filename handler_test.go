@@ -0,0 +1,110 @@
+package log_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"testing/slogtest"
+	"time"
+
+	"go.followtheprocess.codes/hue"
+	"go.followtheprocess.codes/log"
+	"go.followtheprocess.codes/test"
+)
+
+func TestHandler(t *testing.T) {
+	hue.Enabled(false) // Force no color
+
+	fixedTime := func() time.Time {
+		return time.Date(2025, time.April, 1, 13, 34, 3, 0, time.UTC)
+	}
+
+	buf := &bytes.Buffer{}
+
+	logger := log.New(buf, log.TimeFunc(fixedTime), log.TimeFormat(time.Kitchen), log.Prefix("slog"))
+
+	slogger := slog.New(logger.Handler())
+
+	slogger.Debug("Filtered by the logger's level")
+	slogger.Info("Hello", slog.String("name", "tom"))
+	slogger.With(slog.Int("id", 1)).WithGroup("request").Warn("Slow", slog.String("method", "GET"), slog.Attr{})
+	slogger.WithGroup("outer").WithGroup("inner").With(slog.Bool("ok", true)).Error("Nested")
+	slogger.WithGroup("").Info("Empty group")
+	slogger.WithGroup("request").InfoContext(log.AppendCtx(t.Context(), slog.String("trace", "abc")), "Context", slog.Int("id", 2))
+	slogger.Info("Groups", slog.Group("req", slog.String("m", "GET"), slog.Group("", slog.Int("n", 1))), slog.Group("empty"))
+	slogger.WithGroup("outer").Info("Inline", slog.Group("", slog.String("c", "d")))
+
+	want := "1:34PM INFO slog:  Hello name=tom\n" +
+		"1:34PM WARN slog:  Slow id=1 request.method=GET\n" +
+		"1:34PM ERROR slog: Nested outer.inner.ok=true\n" +
+		"1:34PM INFO slog:  Empty group\n" +
+		"1:34PM INFO slog:  Context trace=abc request.id=2\n" +
+		"1:34PM INFO slog:  Groups req.m=GET req.n=1\n" +
+		"1:34PM INFO slog:  Inline outer.c=d\n"
+
+	test.Diff(t, buf.String(), want)
+}
+
+func TestSetAsSlogDefault(t *testing.T) {
+	hue.Enabled(false) // Force no color
+
+	previous := slog.Default()
+
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	fixedTime := func() time.Time {
+		return time.Date(2025, time.April, 1, 13, 34, 3, 0, time.UTC)
+	}
+
+	buf := &bytes.Buffer{}
+
+	logger := log.New(buf, log.TimeFunc(fixedTime), log.TimeFormat(time.Kitchen))
+	logger.SetAsSlogDefault()
+
+	slog.Info("From slog", slog.Int("answer", 42))
+
+	test.Equal(t, buf.String(), "1:34PM INFO:  From slog answer=42\n")
+}
+
+func TestHandlerConformance(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	newHandler := func(t *testing.T) slog.Handler {
+		buf.Reset()
+		return log.New(buf, log.WithJSON(), log.WithLevel(log.LevelDebug)).Handler()
+	}
+
+	// Each JSON line as a map, with the flattened group keys e.g. "G.a" nested
+	// back into maps as slogtest expects
+	result := func(t *testing.T) map[string]any {
+		t.Helper()
+
+		var flat map[string]any
+		test.Ok(t, json.Unmarshal(buf.Bytes(), &flat))
+
+		nested := make(map[string]any, len(flat))
+
+		for key, value := range flat {
+			parts := strings.Split(key, ".")
+			current := nested
+
+			for _, part := range parts[:len(parts)-1] {
+				next, ok := current[part].(map[string]any)
+				if !ok {
+					next = make(map[string]any)
+					current[part] = next
+				}
+
+				current = next
+			}
+
+			current[parts[len(parts)-1]] = value
+		}
+
+		return nested
+	}
+
+	slogtest.Run(t, newHandler, result)
+}
@@ -0,0 +1,47 @@
+package log
+
+import (
+	"sync"
+	"time"
+)
+
+// heartbeatMsg is the message of the lines written by [Logger.Heartbeat].
+const heartbeatMsg = "heartbeat"
+
+// Heartbeat writes a debug level "heartbeat" line every d in the background, until the
+// returned stop function is called, to prove the program is alive to anything
+// watching its output during long periods where it would otherwise be silent.
+//
+//	stop := logger.Heartbeat(30 * time.Second)
+//	defer stop()
+//
+// stop waits for the background goroutine to exit, so no heartbeat is written after it
+// returns, and is safe to call more than once. If the logger is discarding its output
+// no goroutine is started at all. Heartbeat panics if d is not positive.
+func (l *Logger) Heartbeat(d time.Duration) (stop func()) {
+	if l.isDiscard {
+		return func() {}
+	}
+
+	ticker := time.NewTicker(d)
+	done := make(chan struct{})
+
+	var wg sync.WaitGroup
+
+	wg.Go(func() {
+		for {
+			select {
+			case <-ticker.C:
+				l.Debug(heartbeatMsg)
+			case <-done:
+				return
+			}
+		}
+	})
+
+	return sync.OnceFunc(func() {
+		ticker.Stop()
+		close(done)
+		wg.Wait()
+	})
+}
@@ -0,0 +1,125 @@
+package log
+
+import (
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Keys used by [Request], [Response] and [Logger.Request].
+const (
+	methodKey        = "method"
+	urlKey           = "url"
+	statusKey        = "status"
+	durationKey      = "duration"
+	contentLengthKey = "content-length"
+	headersKey       = "headers"
+	requestIDKey     = "request_id"
+)
+
+// sensitiveHeaders are the (canonical) headers whose values are never logged.
+//
+//nolint:gochecknoglobals // Constant lookup table
+var sensitiveHeaders = []string{"Authorization", "Cookie", "Proxy-Authorization", "Set-Cookie"}
+
+// Request returns the conventional attrs for an HTTP request: its method, URL, content
+// length (if known) and headers (if any), for use with the log methods:
+//
+//	logger.Info("Request", log.Request(r)...)
+//
+// Any password in the URL is redacted, as are the values of headers that typically carry
+// credentials: Authorization, Proxy-Authorization, Cookie and Set-Cookie. A nil request
+// returns nil.
+func Request(r *http.Request) []slog.Attr {
+	if r == nil {
+		return nil
+	}
+
+	attrs := []slog.Attr{slog.String(methodKey, r.Method)}
+
+	if r.URL != nil {
+		attrs = append(attrs, slog.String(urlKey, r.URL.Redacted()))
+	}
+
+	if r.ContentLength > 0 {
+		attrs = append(attrs, slog.Int64(contentLengthKey, r.ContentLength))
+	}
+
+	if len(r.Header) != 0 {
+		attrs = append(attrs, headerAttr(r.Header))
+	}
+
+	return attrs
+}
+
+// Request returns a new [Logger] for handling a single request, with a freshly generated
+// request ID attached as the persistent attr request_id, along with the ID itself so it
+// can be passed on, e.g. in a response header:
+//
+//	func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+//		logger, id := s.logger.Request()
+//		w.Header().Set("X-Request-Id", id)
+//		logger.Info("Handling", log.Request(r)...) // ... request_id=1u3m0qs5eo0tl method=GET ...
+//	}
+//
+// The ID is a short random base 32 string. It's cheap to generate and unique enough to
+// correlate the log lines of a request, but isn't cryptographically secure so must not be
+// used for anything that needs to be unguessable.
+func (l *Logger) Request() (*Logger, string) {
+	id := strconv.FormatUint(rand.Uint64(), 32) //nolint:gosec // Only needs to be unique, not secure
+
+	return l.With(slog.String(requestIDKey, id)), id
+}
+
+// Response returns the conventional attrs for an HTTP response: its status code, how long
+// the request took and the content length (if known):
+//
+//	start := time.Now()
+//	resp, err := client.Do(req)
+//	...
+//	logger.Info("Response", log.Response(resp, time.Since(start))...)
+//
+// A nil response returns just the duration.
+func Response(resp *http.Response, elapsed time.Duration) []slog.Attr {
+	if resp == nil {
+		return []slog.Attr{slog.Duration(durationKey, elapsed)}
+	}
+
+	attrs := []slog.Attr{
+		slog.Int(statusKey, resp.StatusCode),
+		slog.Duration(durationKey, elapsed),
+	}
+
+	if resp.ContentLength >= 0 {
+		attrs = append(attrs, slog.Int64(contentLengthKey, resp.ContentLength))
+	}
+
+	return attrs
+}
+
+// headerAttr returns a group attr of the headers in header, sorted by name with multiple
+// values comma separated and sensitive values redacted.
+func headerAttr(header http.Header) slog.Attr {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	attrs := make([]any, 0, len(names))
+	for _, name := range names {
+		value := strings.Join(header[name], ", ")
+		if slices.Contains(sensitiveHeaders, http.CanonicalHeaderKey(name)) {
+			value = redacted
+		}
+
+		attrs = append(attrs, slog.String(name, value))
+	}
+
+	return slog.Group(headersKey, attrs...)
+}
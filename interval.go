@@ -0,0 +1,87 @@
+package log
+
+import (
+	"log/slog"
+	"time"
+
+	"go.followtheprocess.codes/hue"
+)
+
+const (
+	// intervalArrow joins the start and end of an [Interval] when colour is enabled,
+	// plainIntervalArrow when it isn't.
+	intervalArrow      = "→"
+	plainIntervalArrow = "->"
+
+	// startJSONKey, endJSONKey and durationJSONKey are the keys of the members of an
+	// [Interval] in JSON output.
+	startJSONKey    = "start"
+	endJSONKey      = "end"
+	durationJSONKey = "duration"
+)
+
+// intervalArrowStyle is the style of the arrow joining the start and end of an [Interval].
+const intervalArrowStyle = hue.Dim
+
+// interval is the value of the attr returned by [Interval].
+type interval struct {
+	start time.Time // When the interval started
+	end   time.Time // When the interval ended
+}
+
+// MarshalJSON implements [json.Marshaler], rendering the interval as an object
+// with "start", "end" and "duration" members.
+func (i interval) MarshalJSON() ([]byte, error) {
+	dst := []byte{'{'}
+	dst = appendJSONString(dst, startJSONKey)
+	dst = append(dst, ':')
+	dst = appendJSONValue(dst, slog.TimeValue(i.start))
+	dst = append(dst, ',')
+	dst = appendJSONString(dst, endJSONKey)
+	dst = append(dst, ':')
+	dst = appendJSONValue(dst, slog.TimeValue(i.end))
+	dst = append(dst, ',')
+	dst = appendJSONString(dst, durationJSONKey)
+	dst = append(dst, ':')
+	dst = appendJSONValue(dst, slog.DurationValue(i.end.Sub(i.start)))
+
+	return append(dst, '}'), nil
+}
+
+// Interval returns an attr recording the span of time between start and end, e.g. of an
+// operation, which is more expressive than logging the two times separately:
+//
+//	logger.Info("Backup complete", log.Interval("window", start, end))
+//	// ... INFO:  Backup complete window=2025-04-01T13:34:03Z→2025-04-01T13:35:33Z (1m30s)
+//
+// The times are formatted like the logger's timestamps (see [TimeFormat]) and the arrow
+// is dimmed, or written as "->" without colour. With [WithJSON], the value is an object
+// with "start", "end" and "duration" members.
+//
+// An end before start isn't an error, the times are shown as given with a negative
+// duration.
+func Interval(key string, start, end time.Time) slog.Attr {
+	return slog.Any(key, interval{start: start, end: end})
+}
+
+// appendInterval appends the " key=start→end (duration)" form of i to dst and returns
+// the extended slice.
+func (l *Logger) appendInterval(dst []byte, key string, i interval) []byte {
+	mode := l.colorMode()
+
+	dst = l.appendKey(dst, key)
+	dst = append(dst, '=')
+	dst = l.appendTimestamp(dst, i.start)
+
+	if colorEnabled(mode) {
+		dst = appendStyled(dst, mode, intervalArrowStyle, intervalArrow)
+	} else {
+		dst = append(dst, plainIntervalArrow...)
+	}
+
+	dst = l.appendTimestamp(dst, i.end)
+	dst = append(dst, ' ', '(')
+	dst = l.appendValue(dst, slog.DurationValue(i.end.Sub(i.start)))
+
+	return append(dst, ')')
+}
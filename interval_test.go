@@ -0,0 +1,73 @@
+package log_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"go.followtheprocess.codes/hue"
+	"go.followtheprocess.codes/log"
+	"go.followtheprocess.codes/test"
+)
+
+func TestInterval(t *testing.T) {
+	hue.Enabled(false) // Force no color
+
+	start := time.Date(2025, time.April, 1, 13, 34, 3, 0, time.UTC)
+	end := start.Add(90 * time.Second)
+
+	tests := []struct {
+		start   time.Time    // Start of the interval
+		end     time.Time    // End of the interval
+		name    string       // Name of the test case
+		want    string       // Expected log output
+		options []log.Option // Options to construct the logger with
+	}{
+		{
+			name:  "plain",
+			start: start,
+			end:   end,
+			want:  "INFO:  Backup complete window=2025-04-01T13:34:03Z->2025-04-01T13:35:33Z (1m30s)\n",
+		},
+		{
+			name:    "time format",
+			start:   start,
+			end:     end,
+			options: []log.Option{log.TimeFormat(time.TimeOnly), log.WithPlain()},
+			want:    "INFO:  Backup complete window=13:34:03->13:35:33 (1m30s)\n",
+		},
+		{
+			name:  "end before start",
+			start: end,
+			end:   start,
+			want:  "INFO:  Backup complete window=2025-04-01T13:35:33Z->2025-04-01T13:34:03Z (-1m30s)\n",
+		},
+		{
+			name:    "colour",
+			start:   start,
+			end:     end,
+			options: []log.Option{log.WithColor(log.ColorAlways)},
+			want: "\x1b[1;36mINFO\x1b[0m:  Backup complete \x1b[35mwindow\x1b[0m=2025-04-01T13:34:03Z" +
+				"\x1b[2m→\x1b[0m2025-04-01T13:35:33Z (1m30s)\n",
+		},
+		{
+			name:    "json",
+			start:   start,
+			end:     end,
+			options: []log.Option{log.WithJSON(), log.TimeFunc(func() time.Time { return end })},
+			want: `{"time":"2025-04-01T13:35:33Z","level":"INFO","msg":"Backup complete","window":` +
+				`{"start":"2025-04-01T13:34:03Z","end":"2025-04-01T13:35:33Z","duration":"1m30s"}}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+
+			logger := log.New(buf, append([]log.Option{log.WithPlain()}, tt.options...)...)
+			logger.Info("Backup complete", log.Interval("window", tt.start, tt.end))
+
+			test.Diff(t, buf.String(), tt.want)
+		})
+	}
+}
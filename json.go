@@ -0,0 +1,270 @@
+package log
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+// Reserved JSON keys, these always appear first and in this order.
+const (
+	timeKey    = "time"
+	levelKey   = "level"
+	serviceKey = "service"
+	prefixKey  = "prefix"
+	messageKey = "msg"
+)
+
+// jsonIndent is the indent used for each nesting level by pretty JSON output.
+const jsonIndent = "  "
+
+// appendJSON appends the JSON form of a log line to dst and returns the extended slice.
+//
+// The reserved fields (time, level, service, prefix and msg) are always written first in that
+// order (the time only if it's not zero), followed by the persistent, per-call, trailing
+// and finally any extra attrs. By default the record is compact and occupies exactly one
+// line (NDJSON), if pretty JSON is enabled it is indented over multiple lines instead.
+func (l *Logger) appendJSON(dst []byte, rec *record) []byte {
+	start := len(dst)
+
+	var scratch [scratchSize]byte
+
+	dst = append(dst, '{')
+
+	// A zero time means there is no time to show, see Logger.Handler
+	if !rec.time.IsZero() {
+		dst = appendJSONString(dst, timeKey)
+		dst = append(dst, ':')
+
+		if l.timePreset == PresetUnix {
+			// Seconds since the epoch are a number, so are written as one
+			dst = l.appendTimestamp(dst, rec.time)
+		} else {
+			dst = appendJSONString(dst, string(l.appendTimestamp(scratch[:0], rec.time)))
+		}
+
+		dst = append(dst, ',')
+	}
+
+	dst = appendJSONString(dst, levelKey)
+	dst = append(dst, ':')
+	dst = appendJSONString(dst, rec.level.label())
+
+	if len(l.service) != 0 {
+		dst = append(dst, ',')
+		dst = appendJSONString(dst, serviceKey)
+		dst = append(dst, ':')
+		dst = appendJSONString(dst, string(l.service))
+	}
+
+	if len(l.prefix) != 0 {
+		dst = append(dst, ',')
+		dst = appendJSONString(dst, prefixKey)
+		dst = append(dst, ':')
+		dst = appendJSONString(dst, string(l.prefix))
+	}
+
+	dst = append(dst, ',')
+	dst = appendJSONString(dst, messageKey)
+	dst = append(dst, ':')
+	dst = appendJSONString(dst, rec.msg)
+
+	for _, group := range [...][]slog.Attr{rec.persistent, rec.attrs, rec.dynamic, rec.trailing, rec.extra} {
+		for _, attr := range group {
+			dst = appendJSONAttr(dst, attr)
+		}
+	}
+
+	dst = append(dst, '}')
+
+	if l.jsonPretty {
+		// The compact form is always valid JSON so Indent can't fail here, but if it
+		// somehow does, the compact record is still better than nothing
+		pretty := &bytes.Buffer{}
+		if err := json.Indent(pretty, dst[start:], "", jsonIndent); err == nil {
+			dst = append(dst[:start], pretty.Bytes()...)
+		}
+	}
+
+	return l.appendEOL(dst)
+}
+
+// appendJSONAttr appends a single `,"key":value` member to dst and returns
+// the extended slice.
+func appendJSONAttr(dst []byte, attr slog.Attr) []byte {
+	if attr.Value.Kind() == slog.KindLogValuer {
+		attr.Value = attr.Value.Resolve()
+	}
+
+	if errs, ok := joinedErrors(attr.Value); ok {
+		for _, expanded := range expandErrors(attr.Key, errs) {
+			dst = appendJSONAttr(dst, expanded)
+		}
+
+		return dst
+	}
+
+	dst = append(dst, ',')
+	dst = appendJSONString(dst, attr.Key)
+	dst = append(dst, ':')
+
+	return appendJSONValue(dst, attr.Value)
+}
+
+// appendJSONValue appends the JSON form of v to dst and returns the extended slice.
+func appendJSONValue(dst []byte, v slog.Value) []byte {
+	if v.Kind() == slog.KindLogValuer {
+		v = v.Resolve()
+	}
+
+	switch v.Kind() {
+	case slog.KindInt64:
+		return strconv.AppendInt(dst, v.Int64(), base10)
+	case slog.KindUint64:
+		return strconv.AppendUint(dst, v.Uint64(), base10)
+	case slog.KindFloat64:
+		f := v.Float64()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			// Not representable as a JSON number
+			return appendJSONString(dst, strconv.FormatFloat(f, 'g', -1, float64Bits))
+		}
+
+		return strconv.AppendFloat(dst, f, 'g', -1, float64Bits)
+	case slog.KindBool:
+		return strconv.AppendBool(dst, v.Bool())
+	case slog.KindString:
+		return appendJSONString(dst, v.String())
+	case slog.KindDuration:
+		return appendJSONString(dst, v.Duration().String())
+	case slog.KindTime:
+		return appendJSONString(dst, v.Time().Format(time.RFC3339Nano))
+	case slog.KindGroup:
+		dst = append(dst, '{')
+
+		for i, attr := range v.Group() {
+			if i != 0 {
+				dst = append(dst, ',')
+			}
+
+			dst = appendJSONString(dst, attr.Key)
+			dst = append(dst, ':')
+			dst = appendJSONValue(dst, attr.Value)
+		}
+
+		return append(dst, '}')
+	default:
+		if err, ok := v.Any().(error); ok {
+			return appendJSONString(dst, err.Error())
+		}
+
+		// Otherwise it would fail to marshal and be rendered as a list of bytes
+		if raw, ok := v.Any().(json.RawMessage); ok && raw != nil && !json.Valid(raw) {
+			return appendJSONString(dst, string(raw))
+		}
+
+		encoded, err := json.Marshal(v.Any())
+		if err != nil {
+			return appendJSONString(dst, v.String())
+		}
+
+		return append(dst, encoded...)
+	}
+}
+
+// appendJSONString appends s to dst as a quoted and escaped JSON string and
+// returns the extended slice.
+//
+// Invalid UTF-8 is replaced with the unicode replacement character.
+func appendJSONString(dst []byte, s string) []byte {
+	const hex = "0123456789abcdef"
+
+	dst = append(dst, '"')
+
+	for i := 0; i < len(s); {
+		if b := s[i]; b < utf8.RuneSelf {
+			switch {
+			case b == '"' || b == '\\':
+				dst = append(dst, '\\', b)
+			case b == '\n':
+				dst = append(dst, '\\', 'n')
+			case b == '\r':
+				dst = append(dst, '\\', 'r')
+			case b == '\t':
+				dst = append(dst, '\\', 't')
+			case b < ' ':
+				dst = append(dst, '\\', 'u', '0', '0', hex[b>>4], hex[b&0xf])
+			default:
+				dst = append(dst, b)
+			}
+
+			i++
+
+			continue
+		}
+
+		char, size := utf8.DecodeRuneInString(s[i:])
+		if char == utf8.RuneError && size == 1 {
+			dst = utf8.AppendRune(dst, utf8.RuneError)
+		} else {
+			dst = append(dst, s[i:i+size]...)
+		}
+
+		i += size
+	}
+
+	return append(dst, '"')
+}
+
+// asRawJSON returns the JSON encoding of the value held by v if it's already JSON, i.e.
+// a [json.RawMessage] or a [json.Marshaler], so it can be shown as is in text output.
+// Marshalers that are also a [fmt.Stringer] are left alone, their String method is
+// assumed to be the better way to show them to a person.
+//
+// The returned JSON may not be valid, it's up to the caller to check.
+func asRawJSON(v slog.Value) ([]byte, bool) {
+	if v.Kind() != slog.KindAny {
+		return nil, false
+	}
+
+	switch value := v.Any().(type) {
+	case json.RawMessage:
+		if value == nil {
+			return []byte("null"), true
+		}
+
+		return value, true
+	case fmt.Stringer:
+		return nil, false
+	case json.Marshaler:
+		if val := reflect.ValueOf(value); val.Kind() == reflect.Pointer && val.IsNil() {
+			return nil, false
+		}
+
+		raw, err := value.MarshalJSON()
+		if err != nil {
+			return nil, false
+		}
+
+		return raw, true
+	default:
+		return nil, false
+	}
+}
+
+// appendRawJSON appends raw to dst compacted onto a single line, for text output, and
+// returns the extended slice. If raw isn't valid JSON it's appended as a quoted string
+// instead.
+func (l *Logger) appendRawJSON(dst, raw []byte) []byte {
+	buf := bytes.NewBuffer(dst)
+	if err := json.Compact(buf, raw); err != nil {
+		return l.appendQuoted(dst, string(raw))
+	}
+
+	return buf.Bytes()
+}
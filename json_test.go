@@ -0,0 +1,152 @@
+package log_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"go.followtheprocess.codes/log"
+	"go.followtheprocess.codes/test"
+)
+
+func TestJSON(t *testing.T) {
+	// Constantly return the same time
+	fixedTime := func() time.Time {
+		fixed, err := time.Parse(time.RFC3339, "2025-04-01T13:34:03Z")
+		test.Ok(t, err)
+
+		return fixed
+	}
+
+	t.Run("compact", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		logger := log.New(buf, log.WithJSON(), log.TimeFunc(fixedTime), log.Prefix("oven"))
+
+		logger.Info("Preheating", slog.Int("temp", 220), slog.Duration("duration", 10*time.Minute))
+		logger.Warn("Smells \"burnt\"\n", slog.Group("pizza", slog.String("flavour", "pepperoni")))
+
+		lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+		test.Equal(t, len(lines), 2, test.Context("expected exactly one line per record"))
+
+		for _, line := range lines {
+			test.True(t, json.Valid([]byte(line)), test.Context("invalid JSON: %s", line))
+		}
+
+		want := `{"time":"2025-04-01T13:34:03Z","level":"INFO","prefix":"oven","msg":"Preheating","temp":220,"duration":"10m0s"}` + "\n" +
+			`{"time":"2025-04-01T13:34:03Z","level":"WARN","prefix":"oven","msg":"Smells \"burnt\"\n","pizza":{"flavour":"pepperoni"}}` + "\n"
+
+		test.Diff(t, buf.String(), want)
+	})
+
+	t.Run("pretty", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		logger := log.New(buf, log.WithJSONPretty(), log.TimeFunc(fixedTime))
+
+		logger.Info("Preheating", slog.Int("temp", 220), slog.Bool("fan", true))
+
+		test.True(t, json.Valid(buf.Bytes()), test.Context("invalid JSON: %s", buf.String()))
+
+		want := `{
+  "time": "2025-04-01T13:34:03Z",
+  "level": "INFO",
+  "msg": "Preheating",
+  "temp": 220,
+  "fan": true
+}
+`
+
+		test.Diff(t, buf.String(), want)
+	})
+	t.Run("service", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		logger := log.New(buf, log.WithJSON(), log.TimeFunc(fixedTime), log.WithService("api"))
+
+		logger.Info("Starting")
+		logger.Prefixed("db").With(slog.Int("pool", 4)).Info("Connected")
+		logger.Named("cache").Warn("Miss")
+
+		want := `{"time":"2025-04-01T13:34:03Z","level":"INFO","service":"api","msg":"Starting"}` + "\n" +
+			`{"time":"2025-04-01T13:34:03Z","level":"INFO","service":"api","prefix":"db","msg":"Connected","pool":4}` + "\n" +
+			`{"time":"2025-04-01T13:34:03Z","level":"WARN","service":"api","prefix":"cache","msg":"Miss"}` + "\n"
+
+		test.Diff(t, buf.String(), want)
+	})
+
+	t.Run("service text", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		logger := log.New(buf, log.WithPlain(), log.WithService("api"))
+
+		logger.Info("Starting")
+		logger.Prefixed("db").Info("Connected")
+
+		test.Diff(t, buf.String(), "INFO api:  Starting\nINFO db:  Connected\n")
+	})
+}
+
+func TestRawJSON(t *testing.T) {
+	tests := []struct {
+		value any    // The value of the payload attr
+		name  string // Name of the test case
+		text  string // Expected plain text line
+		json  string // Expected JSON line
+	}{
+		{
+			name:  "valid",
+			value: json.RawMessage(`{"id": 1, "tags": ["a", "b"]}`),
+			text:  `INFO:  Received payload={"id":1,"tags":["a","b"]}` + "\n",
+			json:  `{"time":"2025-04-01T13:34:03Z","level":"INFO","msg":"Received","payload":{"id":1,"tags":["a","b"]}}` + "\n",
+		},
+		{
+			name:  "malformed",
+			value: json.RawMessage(`{"id": 1`),
+			text:  `INFO:  Received payload="{\"id\": 1"` + "\n",
+			json:  `{"time":"2025-04-01T13:34:03Z","level":"INFO","msg":"Received","payload":"{\"id\": 1"}` + "\n",
+		},
+		{
+			name:  "nil",
+			value: json.RawMessage(nil),
+			text:  "INFO:  Received payload=null\n",
+			json:  `{"time":"2025-04-01T13:34:03Z","level":"INFO","msg":"Received","payload":null}` + "\n",
+		},
+		{
+			name:  "marshaler",
+			value: jsonPoint{X: 1, Y: 2},
+			text:  `INFO:  Received payload=[1,2]` + "\n",
+			json:  `{"time":"2025-04-01T13:34:03Z","level":"INFO","msg":"Received","payload":[1,2]}` + "\n",
+		},
+	}
+
+	fixedTime := func() time.Time {
+		return time.Date(2025, time.April, 1, 13, 34, 3, 0, time.UTC)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			logger := log.New(buf, log.WithPlain())
+
+			logger.Info("Received", slog.Any("payload", tt.value))
+			test.Diff(t, buf.String(), tt.text)
+
+			buf.Reset()
+
+			logger = log.New(buf, log.WithJSON(), log.TimeFunc(fixedTime))
+
+			logger.Info("Received", slog.Any("payload", tt.value))
+			test.Diff(t, buf.String(), tt.json)
+		})
+	}
+}
+
+// jsonPoint is a [json.Marshaler] encoding itself as a pair.
+type jsonPoint struct {
+	X, Y int
+}
+
+func (p jsonPoint) MarshalJSON() ([]byte, error) {
+	return fmt.Appendf(nil, "[%d, %d]", p.X, p.Y), nil
+}
@@ -0,0 +1,60 @@
+package log
+
+import (
+	"cmp"
+	"log/slog"
+	"slices"
+)
+
+// AttrLayout decides the order in which attrs are rendered on a log line, see [WithAttrLayout].
+//
+// It is passed the logger's persistent attrs (those attached with [Logger.With]) and the
+// attrs passed to the individual log call and returns all of them in the order they should
+// be rendered. An AttrLayout must not modify either of its arguments.
+//
+// The presets [InsertionOrder], [Alphabetical] and [PersistentFirst] cover the common cases,
+// and [SortedBy] builds one from a comparison function.
+type AttrLayout func(persistent, call []slog.Attr) []slog.Attr
+
+// InsertionOrder is an [AttrLayout] that renders persistent attrs followed by per-call attrs,
+// each in the order they were given. This is the default.
+func InsertionOrder(persistent, call []slog.Attr) []slog.Attr {
+	return slices.Concat(persistent, call)
+}
+
+// Alphabetical is an [AttrLayout] that renders all attrs sorted by key, regardless of
+// whether they are persistent or per-call. Attrs with the same key keep their insertion order.
+func Alphabetical(persistent, call []slog.Attr) []slog.Attr {
+	attrs := slices.Concat(persistent, call)
+	slices.SortStableFunc(attrs, byKey)
+
+	return attrs
+}
+
+// PersistentFirst is an [AttrLayout] that renders persistent attrs first, sorted by key,
+// followed by the per-call attrs, also sorted by key. This keeps context attached with
+// [Logger.With] in a consistent position at the front of every line.
+func PersistentFirst(persistent, call []slog.Attr) []slog.Attr {
+	attrs := slices.Concat(persistent, call)
+	slices.SortStableFunc(attrs[:len(persistent)], byKey)
+	slices.SortStableFunc(attrs[len(persistent):], byKey)
+
+	return attrs
+}
+
+// SortedBy returns an [AttrLayout] that renders all attrs sorted by the comparison
+// function cmp, which should behave like the comparison function passed to [slices.SortFunc].
+// The sort is stable.
+func SortedBy(cmp func(a, b slog.Attr) int) AttrLayout {
+	return func(persistent, call []slog.Attr) []slog.Attr {
+		attrs := slices.Concat(persistent, call)
+		slices.SortStableFunc(attrs, cmp)
+
+		return attrs
+	}
+}
+
+// byKey orders attrs by their key.
+func byKey(a, b slog.Attr) int {
+	return cmp.Compare(a.Key, b.Key)
+}
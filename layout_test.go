@@ -0,0 +1,88 @@
+package log_test
+
+import (
+	"bytes"
+	"cmp"
+	"log/slog"
+	"testing"
+	"time"
+
+	"go.followtheprocess.codes/hue"
+	"go.followtheprocess.codes/log"
+	"go.followtheprocess.codes/test"
+)
+
+func TestAttrLayout(t *testing.T) {
+	hue.Enabled(false) // Force no color
+
+	// Constantly return the same time
+	fixedTime := func() time.Time {
+		fixed, err := time.Parse(time.RFC3339, "2025-04-01T13:34:03Z")
+		test.Ok(t, err)
+
+		return fixed
+	}
+
+	tests := []struct {
+		layout         log.AttrLayout // The layout under test
+		name           string         // Name of the test case
+		want           string         // Expected log line
+		wantPersistent string         // Expected rendering of just the persistent attrs
+	}{
+		{
+			name:           "default",
+			layout:         nil,
+			want:           "2025-04-01T13:34:03Z INFO:  msg user=tom id=1 status=200 bytes=10 agent=curl\n",
+			wantPersistent: "user=tom id=1",
+		},
+		{
+			name:           "insertion order",
+			layout:         log.InsertionOrder,
+			want:           "2025-04-01T13:34:03Z INFO:  msg user=tom id=1 status=200 bytes=10 agent=curl\n",
+			wantPersistent: "user=tom id=1",
+		},
+		{
+			name:           "alphabetical",
+			layout:         log.Alphabetical,
+			want:           "2025-04-01T13:34:03Z INFO:  msg agent=curl bytes=10 id=1 status=200 user=tom\n",
+			wantPersistent: "id=1 user=tom",
+		},
+		{
+			name:           "persistent first",
+			layout:         log.PersistentFirst,
+			want:           "2025-04-01T13:34:03Z INFO:  msg id=1 user=tom agent=curl bytes=10 status=200\n",
+			wantPersistent: "id=1 user=tom",
+		},
+		{
+			name: "custom comparator",
+			layout: log.SortedBy(func(a, b slog.Attr) int {
+				// Reverse alphabetical
+				return cmp.Compare(b.Key, a.Key)
+			}),
+			want:           "2025-04-01T13:34:03Z INFO:  msg user=tom status=200 id=1 bytes=10 agent=curl\n",
+			wantPersistent: "user=tom id=1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+
+			options := []log.Option{log.TimeFunc(fixedTime)}
+			if tt.layout != nil {
+				options = append(options, log.WithAttrLayout(tt.layout))
+			}
+
+			logger := log.New(buf, options...).With(slog.String("user", "tom"), slog.Int("id", 1))
+
+			logger.Info("msg", slog.Int("status", 200), slog.Int("bytes", 10), slog.String("agent", "curl"))
+
+			test.Diff(t, buf.String(), tt.want)
+
+			// The logger's own persistent attrs must not have been reordered in place
+			buf.Reset()
+			logger.Info("msg")
+			test.Diff(t, buf.String(), "2025-04-01T13:34:03Z INFO:  msg "+tt.wantPersistent+"\n")
+		})
+	}
+}
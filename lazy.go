@@ -0,0 +1,23 @@
+package log
+
+import "log/slog"
+
+// lazy is a [slog.LogValuer] that computes its value only when it's resolved.
+type lazy func() any
+
+// LogValue implements [slog.LogValuer], calling the wrapped function.
+func (fn lazy) LogValue() slog.Value {
+	return slog.AnyValue(fn())
+}
+
+// Lazy returns a [slog.Attr] whose value is computed by calling fn, but only if
+// the log line it's passed to is actually written. This defers the cost of an
+// expensive value to when it's needed:
+//
+//	logger.Debug("Loaded", log.Lazy("checksum", func() any { return checksum(data) }))
+//
+// fn is called at most once per log line it's rendered in, and not at all if the
+// line is filtered out by level. If fn returns a [slog.LogValuer], that is resolved too.
+func Lazy(key string, fn func() any) slog.Attr {
+	return slog.Any(key, lazy(fn))
+}
@@ -0,0 +1,67 @@
+package log_test
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+	"time"
+
+	"go.followtheprocess.codes/hue"
+	"go.followtheprocess.codes/log"
+	"go.followtheprocess.codes/test"
+)
+
+func TestLazy(t *testing.T) {
+	hue.Enabled(false) // Force no color
+
+	fixedTime := func() time.Time {
+		return time.Date(2025, time.April, 1, 13, 34, 3, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name    string       // Name of the test case
+		want    string       // Expected log output
+		options []log.Option // Options to construct the logger with
+	}{
+		{
+			name: "text",
+			want: "2025-04-01T13:34:03Z INFO:  Loaded checksum=abc123\n",
+		},
+		{
+			name:    "json",
+			options: []log.Option{log.WithJSON()},
+			want:    `{"time":"2025-04-01T13:34:03Z","level":"INFO","msg":"Loaded","checksum":"abc123"}` + "\n",
+		},
+		{
+			name: "value colour",
+			options: []log.Option{
+				log.WithValueColorFunc(func(key string, value slog.Value) (hue.Style, bool) {
+					return hue.Green, value.Kind() == slog.KindString
+				}),
+			},
+			want: "2025-04-01T13:34:03Z INFO:  Loaded checksum=abc123\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+
+			calls := 0
+			checksum := log.Lazy("checksum", func() any {
+				calls++
+				return "abc123"
+			})
+
+			logger := log.New(buf, append([]log.Option{log.TimeFunc(fixedTime)}, tt.options...)...)
+
+			logger.Debug("Filtered", checksum)
+			test.Equal(t, calls, 0, test.Context("fn called for a filtered log line"))
+
+			logger.Info("Loaded", checksum)
+			test.Equal(t, calls, 1, test.Context("fn should be called exactly once"))
+
+			test.Diff(t, buf.String(), tt.want)
+		})
+	}
+}
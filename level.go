@@ -1,5 +1,13 @@
 package log
 
+import (
+	"sync"
+	"sync/atomic"
+	"unicode/utf8"
+
+	"go.followtheprocess.codes/hue"
+)
+
 // Level is a log level.
 type Level int
 
@@ -27,19 +35,149 @@ const (
 	infoString  = "INFO"
 	warnString  = "WARN"
 	errorString = "ERROR"
+
+	// labelWidth is the width of the longest built in level label, shorter labels
+	// are padded to this width so messages line up.
+	labelWidth = 5
 )
 
-// Pre-converted label bytes so the hot path can append them with
-// [hue.Style.AppendText] without allocating a fresh []byte each log call.
+// LevelCase is the letter case of the built in level labels, see [WithLevelCase].
+type LevelCase int
+
+const (
+	// CaseUpper renders level labels in upper case e.g. INFO. This is the default.
+	CaseUpper LevelCase = iota
+
+	// CaseLower renders level labels in lower case e.g. info.
+	CaseLower
+
+	// CaseTitle renders level labels in title case e.g. Info.
+	CaseTitle
+)
+
+// The built in level labels in each [LevelCase], so the hot path can pick one
+// without transforming it on every log call.
 //
-//nolint:gochecknoglobals // Constants but []byte can't be constant
+//nolint:gochecknoglobals // Constant lookup tables
 var (
-	debugBytes = []byte(debugString)
-	infoBytes  = []byte(infoString)
-	warnBytes  = []byte(warnString)
-	errorBytes = []byte(errorString)
+	debugLabels = [...]string{CaseUpper: debugString, CaseLower: "debug", CaseTitle: "Debug"}
+	infoLabels  = [...]string{CaseUpper: infoString, CaseLower: "info", CaseTitle: "Info"}
+	warnLabels  = [...]string{CaseUpper: warnString, CaseLower: "warn", CaseTitle: "Warn"}
+	errorLabels = [...]string{CaseUpper: errorString, CaseLower: "error", CaseTitle: "Error"}
 )
 
+// globalMinLevel is the process wide minimum level set by [SetGlobalMinLevel], nil if unset.
+//
+//nolint:gochecknoglobals // Process wide by design
+var globalMinLevel atomic.Pointer[Level]
+
+// levelOverridden is set the first time anything overrides the level of loggers at
+// runtime, i.e. [SetGlobalMinLevel] or [Logger.MutePrefix]. Until then, and unless the
+// logger has level rules of its own, checking a level is a single comparison.
+//
+//nolint:gochecknoglobals // Process wide by design
+var levelOverridden atomic.Bool
+
+// SetGlobalMinLevel sets a process wide minimum level that applies to every [Logger],
+// regardless of how each is configured. It is checked before the logger's own level so
+// logs below it are never shown, even from a logger configured with [LevelDebug].
+//
+// This is useful for an application to floor all logging (including that of any
+// libraries it uses) at e.g. [LevelWarn] in production. Combined with a build tag and
+// [Logger.Enabled], expensive debug logging can be cut down to almost nothing in
+// release builds:
+//
+//	//go:build !debug
+//
+//	package main
+//
+//	func init() {
+//		log.SetGlobalMinLevel(log.LevelWarn)
+//	}
+//
+// SetGlobalMinLevel may be called safely from concurrently executing goroutines.
+func SetGlobalMinLevel(level Level) {
+	globalMinLevel.Store(&level)
+	levelOverridden.Store(true)
+}
+
+// customLevel is a level registered with [RegisterLevel].
+type customLevel struct {
+	name  string    // The label shown in logs
+	style hue.Style // The style the label is shown in
+}
+
+// customLevels holds all the levels registered with [RegisterLevel].
+//
+//nolint:gochecknoglobals // Process wide registry
+var customLevels = struct {
+	levels map[Level]customLevel
+	mu     sync.RWMutex
+}{
+	levels: make(map[Level]customLevel),
+}
+
+// RegisterLevel registers a custom level in addition to the built in ones, so it
+// can be logged at with [Logger.Log], filtered with [WithLevel] and is shown in
+// logs as name, rendered in the given style.
+//
+// Just like [log/slog], levels are arbitrary integers with higher values being more
+// severe, so a level between info and warn could be registered with:
+//
+//	const LevelNotice log.Level = 2
+//
+//	func init() {
+//		log.RegisterLevel(LevelNotice, "NOTICE", hue.Green|hue.Bold)
+//	}
+//
+// Registering a built in level has no effect and registering the same value twice
+// replaces the previous registration. RegisterLevel is safe to call concurrently but
+// is intended to be called during program initialisation, before any logging.
+func RegisterLevel(value Level, name string, style hue.Style) {
+	switch value {
+	case LevelDebug, LevelInfo, LevelWarn, LevelError:
+		return
+	}
+
+	customLevels.mu.Lock()
+	defer customLevels.mu.Unlock()
+
+	customLevels.levels[value] = customLevel{name: name, style: style}
+}
+
+// lookupCustom returns the custom level registered at value, if there is one.
+func lookupCustom(value Level) (customLevel, bool) {
+	customLevels.mu.RLock()
+	defer customLevels.mu.RUnlock()
+
+	custom, ok := customLevels.levels[value]
+
+	return custom, ok
+}
+
+// LevelFromVerbosity returns the level for a command line program given the number of
+// times -v/--verbose and -q/--quiet were passed, following the common convention:
+//
+//   - Neither: [LevelInfo]
+//   - -v (or more): [LevelDebug]
+//   - -q: [LevelWarn]
+//   - -qq (or more): [LevelError]
+//
+// The flags cancel each other out so -vq is the same as neither, and counts below
+// zero are treated as zero.
+func LevelFromVerbosity(verbose, quiet int) Level {
+	switch net := max(verbose, 0) - max(quiet, 0); {
+	case net >= 1:
+		return LevelDebug
+	case net == 0:
+		return LevelInfo
+	case net == -1:
+		return LevelWarn
+	default:
+		return LevelError
+	}
+}
+
 // String returns the stylised representation of the log level.
 func (l Level) String() string {
 	switch l {
@@ -52,24 +190,105 @@ func (l Level) String() string {
 	case LevelError:
 		return errorStyle.Text(errorString)
 	default:
+		if custom, ok := lookupCustom(l); ok {
+			return custom.style.Text(custom.name)
+		}
+
 		return "unknown"
 	}
 }
 
 // appendTo appends the stylised level label to dst and returns the extended
 // slice. It is the allocation-light equivalent of [Level.String] used on the
-// logging hot path.
-func (l Level) appendTo(dst []byte) []byte {
+// logging hot path, styled according to mode with built in labels in the given case.
+func (l Level) appendTo(dst []byte, mode ColorMode, letterCase LevelCase) []byte {
 	switch l {
 	case LevelDebug:
-		return debugStyle.AppendText(dst, debugBytes)
+		return appendStyled(dst, mode, debugStyle, debugLabels[letterCase])
 	case LevelInfo:
-		return infoStyle.AppendText(dst, infoBytes)
+		return appendStyled(dst, mode, infoStyle, infoLabels[letterCase])
 	case LevelWarn:
-		return warnStyle.AppendText(dst, warnBytes)
+		return appendStyled(dst, mode, warnStyle, warnLabels[letterCase])
 	case LevelError:
-		return errorStyle.AppendText(dst, errorBytes)
+		return appendStyled(dst, mode, errorStyle, errorLabels[letterCase])
 	default:
+		if custom, ok := lookupCustom(l); ok {
+			return appendStyled(dst, mode, custom.style, custom.name)
+		}
+
 		return append(dst, "unknown"...)
 	}
 }
+
+// appendBadge appends the stylised single character badge for the level to dst and
+// returns the extended slice. The badge is the first character of the label shown
+// by [Level.appendTo], see [WithCompactLevels].
+func (l Level) appendBadge(dst []byte, mode ColorMode, letterCase LevelCase) []byte {
+	label := l.text(letterCase)
+	_, size := utf8.DecodeRuneInString(label)
+
+	return appendStyled(dst, mode, l.style(), label[:size])
+}
+
+// text returns the plain label shown for the level in text output, with built in
+// labels in the given case.
+func (l Level) text(letterCase LevelCase) string {
+	switch l {
+	case LevelDebug:
+		return debugLabels[letterCase]
+	case LevelInfo:
+		return infoLabels[letterCase]
+	case LevelWarn:
+		return warnLabels[letterCase]
+	case LevelError:
+		return errorLabels[letterCase]
+	default:
+		return l.label()
+	}
+}
+
+// style returns the style used for the level's label.
+func (l Level) style() hue.Style {
+	switch l {
+	case LevelDebug:
+		return debugStyle
+	case LevelInfo:
+		return infoStyle
+	case LevelWarn:
+		return warnStyle
+	case LevelError:
+		return errorStyle
+	default:
+		if custom, ok := lookupCustom(l); ok {
+			return custom.style
+		}
+
+		return 0
+	}
+}
+
+// label returns the plain, unstyled label for the level, as used in structured output.
+func (l Level) label() string {
+	switch l {
+	case LevelDebug:
+		return debugString
+	case LevelInfo:
+		return infoString
+	case LevelWarn:
+		return warnString
+	case LevelError:
+		return errorString
+	default:
+		if custom, ok := lookupCustom(l); ok {
+			return custom.name
+		}
+
+		return "unknown"
+	}
+}
+
+// padding returns the number of spaces needed after the level's label to pad it
+// to width runes, 0 if the label is already at least that wide.
+func (l Level) padding(width int) int {
+	return max(width-utf8.RuneCountInString(l.label()), 0)
+}
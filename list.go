@@ -0,0 +1,92 @@
+package log
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strings"
+)
+
+// ListFormat controls how slice and array attr values are rendered in text logs,
+// see [WithListFormat].
+type ListFormat int
+
+const (
+	// ListGoSyntax renders lists as Go does with fmt's %v verb e.g. [a b c]. This is
+	// the default.
+	ListGoSyntax ListFormat = iota
+
+	// ListComma renders lists as comma separated values e.g. a,b,c. Nested lists are
+	// wrapped in square brackets e.g. [a,b],[c].
+	ListComma
+
+	// ListJSON renders lists as JSON arrays e.g. ["a","b","c"].
+	ListJSON
+)
+
+// formatList formats v according to the logger's list format if it holds a slice or
+// array, returning the formatted list and true. If v isn't a list, is a []byte (which
+// is raw data rather than a list) or the list format is [ListGoSyntax], it returns "" and false.
+func (l *Logger) formatList(v slog.Value) (string, bool) {
+	if l.listFormat == ListGoSyntax || v.Kind() != slog.KindAny {
+		return "", false
+	}
+
+	list := v.Any()
+	if !isList(reflect.ValueOf(list)) {
+		return "", false
+	}
+
+	switch l.listFormat {
+	case ListComma:
+		builder := &strings.Builder{}
+		writeCommaList(builder, reflect.ValueOf(list))
+
+		return builder.String(), true
+	case ListJSON:
+		encoded, err := json.Marshal(list)
+		if err != nil {
+			return "", false
+		}
+
+		return string(encoded), true
+	default:
+		return "", false
+	}
+}
+
+// writeCommaList writes the elements of list to builder separated by commas,
+// nested lists are wrapped in square brackets.
+func writeCommaList(builder *strings.Builder, list reflect.Value) {
+	for i := range list.Len() {
+		if i != 0 {
+			builder.WriteByte(',')
+		}
+
+		element := list.Index(i)
+		for element.Kind() == reflect.Interface && !element.IsNil() {
+			element = element.Elem()
+		}
+
+		if isList(element) {
+			builder.WriteByte('[')
+			writeCommaList(builder, element)
+			builder.WriteByte(']')
+
+			continue
+		}
+
+		fmt.Fprint(builder, element.Interface())
+	}
+}
+
+// isList reports whether v is a slice or array, other than a []byte.
+func isList(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		return v.Type().Elem().Kind() != reflect.Uint8
+	default:
+		return false
+	}
+}
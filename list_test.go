@@ -0,0 +1,94 @@
+package log_test
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+	"time"
+
+	"go.followtheprocess.codes/hue"
+	"go.followtheprocess.codes/log"
+	"go.followtheprocess.codes/test"
+)
+
+func TestListFormat(t *testing.T) {
+	hue.Enabled(false) // Force no color
+
+	tests := []struct {
+		value  any            // The list to log
+		name   string         // Name of the test case
+		want   string         // Expected log line
+		format log.ListFormat // The list format under test
+	}{
+		{
+			name:   "go syntax",
+			format: log.ListGoSyntax,
+			value:  []string{"merlot", "malbec", "rioja"},
+			want:   "1:34PM INFO:  Wine choices=\"[merlot malbec rioja]\"\n",
+		},
+		{
+			name:   "go syntax nested",
+			format: log.ListGoSyntax,
+			value:  [][]string{{"a", "b"}, {"c"}},
+			want:   "1:34PM INFO:  Wine choices=\"[[a b] [c]]\"\n",
+		},
+		{
+			name:   "comma",
+			format: log.ListComma,
+			value:  []string{"merlot", "malbec", "rioja"},
+			want:   "1:34PM INFO:  Wine choices=merlot,malbec,rioja\n",
+		},
+		{
+			name:   "comma nested",
+			format: log.ListComma,
+			value:  [][]string{{"a", "b"}, {"c"}},
+			want:   "1:34PM INFO:  Wine choices=[a,b],[c]\n",
+		},
+		{
+			name:   "comma needs quotes",
+			format: log.ListComma,
+			value:  []string{"pinot noir", "rioja"},
+			want:   "1:34PM INFO:  Wine choices=\"pinot noir,rioja\"\n",
+		},
+		{
+			name:   "comma array",
+			format: log.ListComma,
+			value:  [3]int{1, 2, 3},
+			want:   "1:34PM INFO:  Wine choices=1,2,3\n",
+		},
+		{
+			name:   "json",
+			format: log.ListJSON,
+			value:  []string{"merlot", "malbec", "rioja"},
+			want:   "1:34PM INFO:  Wine choices=[\"merlot\",\"malbec\",\"rioja\"]\n",
+		},
+		{
+			name:   "json nested",
+			format: log.ListJSON,
+			value:  [][]string{{"a", "b"}, {"c"}},
+			want:   "1:34PM INFO:  Wine choices=[[\"a\",\"b\"],[\"c\"]]\n",
+		},
+		{
+			name:   "not a list",
+			format: log.ListComma,
+			value:  []byte("bytes"),
+			want:   "1:34PM INFO:  Wine choices=\"[98 121 116 101 115]\"\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+
+			fixedTime := func() time.Time {
+				return time.Date(2025, time.April, 1, 13, 34, 3, 0, time.UTC)
+			}
+
+			logger := log.New(buf, log.TimeFunc(fixedTime), log.TimeFormat(time.Kitchen), log.WithListFormat(tt.format))
+
+			logger.Info("Wine", slog.Any("choices", tt.value))
+
+			test.Diff(t, buf.String(), tt.want)
+		})
+	}
+}
@@ -9,11 +9,17 @@
 package log // import "go.followtheprocess.codes/log"
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
 	"io"
 	"log/slog"
+	"reflect"
+	"runtime/debug"
 	"slices"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 	"unicode"
 	"unicode/utf8"
@@ -30,11 +36,55 @@ const (
 	// hold a typical line without reallocating.
 	bufferSize = 256
 
+	// maxBufferSize is the capacity above which buffers are not returned to the pool,
+	// approx 65kb.
+	maxBufferSize = 64 << 10
+
 	// base10 is the radix used to format integer attribute values.
 	base10 = 10
 
 	// float64Bits is the bit size used to format floating point attribute values.
 	float64Bits = 64
+
+	// maxComponents is the maximum number of components of a line written separately
+	// by an unbuffered logger, anything more is written along with the last one.
+	maxComponents = 64
+
+	// extraAttrs is the number of attrs the logger may add to a line itself
+	// that fit on the stack without allocating.
+	extraAttrs = 4
+
+	// clearLine is a carriage return followed by the ANSI erase line sequence, it
+	// moves the cursor to the start of the line and clears anything already there.
+	clearLine = "\r\x1b[K"
+
+	// lf and crlf are the line endings allowed by [WithLineEnding].
+	lf   = "\n"
+	crlf = "\r\n"
+
+	// separatorWidth is the width of a separator rule when the logger isn't
+	// writing to a terminal, or its width can't be determined.
+	separatorWidth = 80
+
+	// defaultDelimiter is the default delimiter between the names of nested
+	// loggers and slog groups, see [WithDelimiter].
+	defaultDelimiter = "."
+
+	// bannerRune is the rune a [Logger.Banner] rule is drawn with, unless
+	// [WithSeparatorRune] is set.
+	bannerRune = '─'
+
+	// panicKey and stackKey are the keys [Logger.Recover] uses for the panic value
+	// and stack trace.
+	panicKey = "panic"
+	stackKey = "stack"
+
+	// seqKey is the key used for the sequence number added by [WithSequenceNumbers].
+	seqKey = "seq"
+
+	// pidKey and hostnameKey are the keys [WithProcessInfo] uses.
+	pidKey      = "pid"
+	hostnameKey = "hostname"
 )
 
 // Styles.
@@ -46,6 +96,13 @@ const (
 	infoStyle      = hue.Cyan | hue.Bold
 	warnStyle      = hue.Yellow | hue.Bold
 	errorStyle     = hue.Red | hue.Bold
+	fastStyle      = hue.Green
+	mediumStyle    = hue.Yellow
+	slowStyle      = hue.Red
+	separatorStyle = hue.Dim
+	bannerStyle    = hue.Bold
+	moreStyle      = hue.Dim
+	sectionStyle   = hue.Dim
 )
 
 // Logger is a command line logger. It is safe to use across concurrently
@@ -53,14 +110,75 @@ const (
 //
 // The zero value is not usable; construct a Logger with [New].
 type Logger struct {
-	w          io.Writer        // Where to write logs to
-	timeFunc   func() time.Time // A function to get the current time, defaults to [time.Now] (with UTC)
-	mu         *sync.Mutex      // Protects w, pointer so that child loggers share the same mutex
-	timeFormat string           // The time format layout string, defaults to [time.RFC3339]
-	prefix     []byte           // Optional prefix to prepend to all log messages, stored as bytes for the hot path
-	attrs      []slog.Attr      // Persistent key value pairs
-	level      Level            // The configured level of this logger, logs below this level are not shown
-	isDiscard  bool             // w == [io.Discard], cached. Only written during construction, before the logger is shared
+	w             io.Writer                                  // Where to write logs to
+	timeFunc      func() time.Time                           // A function to get the current time, defaults to [time.Now] (with UTC)
+	start         time.Time                                  // When the root logger was created, only set if hybridTime is
+	attrLayout    AttrLayout                                 // Optional ordering of attrs, nil means persistent then per-call in insertion order
+	mu            *sync.Mutex                                // Protects w, pointer so that child loggers share the same mutex
+	jsonW         io.Writer                                  // If set, log lines are also written here as JSON, see NewDual
+	jsonMu        *sync.Mutex                                // Protects jsonW, shared with child loggers like mu
+	sinks         []*Logger                                  // If set, log lines are rendered by and written to each of these instead of w, see NewTee
+	captured      *[]string                                  // In capture mode, everything written so far, protected by mu and shared with child loggers
+	lineHook      func(Level, []byte)                        // Optional hook called with every rendered line before it's written
+	dynamicAttrs  func() []slog.Attr                         // Called for attrs to add to each emitted line, see WithDynamicAttrs
+	isTTY         *atomic.Bool                               // Whether w is a terminal for ColorDetect, shared with child loggers using the same w
+	color         *atomic.Int32                              // The ColorMode, shared with child loggers so it can be changed at runtime with SetColor
+	tails         *tailHub                                   // Broadcasts written lines to readers from Tail, shared with child loggers
+	muted         *mutedPrefixes                             // Prefixes muted at runtime, shared with child loggers, see MutePrefix
+	shadowed      *atomic.Uint64                             // Shared count of persistent attrs shadowed by per-call ones, nil if not enabled, see WithShadowWarnings
+	seq           *atomic.Uint64                             // Shared counter for sequence numbers, nil if not enabled, see WithSequenceNumbers
+	records       chan<- Record                              // If set, log lines are sent here as records rather than being written to w
+	prefixLevels  map[string]Level                           // Optional per-prefix minimum levels, overriding level for loggers with that prefix
+	levelPrefixes map[Level]string                           // Optional per-level tags rendered after the level label
+	unitHints     map[string]string                          // Optional units appended to numeric attr values, by key
+	errorHandler  func(error)                                // Optional handler called when writing a log line fails
+	valueColor    func(string, slog.Value) (hue.Style, bool) // Optional function choosing a style for attr values
+	timeFormat    string                                     // The time format layout string, defaults to [time.RFC3339]
+	delimiter     string                                     // Joins the names from Named and slog groups, see WithDelimiter
+	once          string                                     // The key limiting the logger to one line per process, see Once
+	callerKey     string                                     // The key of the caller attr, see WithCallerKey
+	prefix        []byte                                     // Optional prefix to prepend to all log messages, stored as bytes for the hot path
+	service       []byte                                     // The name of the service, a reserved JSON key and the text prefix if there's no other, see WithService
+	attrs         []slog.Attr                                // Persistent key value pairs
+	trailing      []slog.Attr                                // Persistent key value pairs rendered after the per-call ones
+	level         Level                                      // The configured level of this logger, logs below this level are not shown
+	clampMin      Level                                      // The lowest the effective minimum level may be, only used if clamped is set
+	clampMax      Level                                      // The highest the effective minimum level may be, only used if clamped is set
+	fastDuration  time.Duration                              // Duration values below this are styled as fast
+	slowDuration  time.Duration                              // Duration values at or above this are styled as slow, 0 disables duration styling
+	wrapWidth     int                                        // Wrap text lines between attrs to fit this display width, 0 means no wrapping
+	levelWidth    int                                        // Pad the level label to this many runes before the colon, 0 means pad after the colon instead
+	maxAttrs      int                                        // Render at most this many attrs per text line, 0 means no limit
+	messageColumn int                                        // Pad or truncate the text line header so messages start at this column, 0 means don't
+	separator     rune                                       // The rune used to draw separator rules, 0 means separators are blank lines
+	quote         rune                                       // The rune values are quoted with, 0 means a double quote, see WithQuoteChar
+	bufferHint    int                                        // Grow line buffers to at least this capacity before use
+	freeList      chan *[]byte                               // Bounded free list of line buffers shared with child loggers, nil means use bufPool
+	timePreset    TimePreset                                 // A preset no layout can express, used in place of timeFormat. The zero value means use timeFormat
+	listFormat    ListFormat                                 // How slice and array attr values are rendered in text
+	levelCase     LevelCase                                  // The letter case of the built in level labels
+	punctuation   hue.Style                                  // The style of the colon ending the text line header, 0 means unstyled
+	isDiscard     bool                                       // w == [io.Discard], cached. Only written during construction, before the logger is shared
+	noLevel       bool                                       // Omit the level label entirely
+	noTimestamp   bool                                       // Omit the timestamp from text lines, see WithPlain
+	hybridTime    bool                                       // Show elapsed time on text lines below warning level, see WithHybridTime
+	json          bool                                       // Write logs as JSON rather than text
+	jsonPretty    bool                                       // Indent JSON logs across multiple lines, only meaningful if json is set
+	goroutineID   bool                                       // Add the ID of the logging goroutine to every line
+	caller        bool                                       // Add the location of the log call to every line, see WithCaller
+	unbuffered    bool                                       // Write each component of a line separately, rather than via a pooled buffer
+	flagBools     bool                                       // Render true bool attrs as just the key and omit false ones
+	smartPaths    bool                                       // Percent encode path and URL values rather than quoting them, see WithSmartPathQuoting
+	msgAttr       bool                                       // Render the message as a msg attr rather than free text
+	attrSections  bool                                       // Bracket the persistent attrs of text lines, see WithAttrSections
+	compactLevels bool                                       // Render the level as a single character badge, see WithCompactLevels
+	prefixByLevel bool                                       // Style the prefix like the level rather than with prefixStyle, see WithLevelColoredPrefix
+	clearLine     bool                                       // Clear the current terminal line before writing each log line
+	autoClearLine bool                                       // Set by WithTerminalClearLine, so clearLine is detected afresh for a new writer
+	autoWrap      bool                                       // Set by WithWrapWidth(0), so wrapWidth is detected afresh for a new writer
+	levelRules    bool                                       // Set if clamped or prefixLevels are, so the level check can skip them otherwise
+	crlf          bool                                       // End lines with \r\n rather than \n, see WithLineEnding
+	clamped       bool                                       // Bound the effective minimum level by clampMin and clampMax, see WithClampLevel
 }
 
 // New returns a new [Logger] configured to write to w.
@@ -74,6 +192,12 @@ func New(w io.Writer, options ...Option) *Logger {
 		timeFormat: time.RFC3339,
 		timeFunc:   func() time.Time { return time.Now().UTC() },
 		mu:         &sync.Mutex{},
+		color:      &atomic.Int32{},
+		isTTY:      &atomic.Bool{},
+		muted:      &mutedPrefixes{},
+		tails:      &tailHub{},
+		delimiter:  defaultDelimiter,
+		callerKey:  defaultCallerKey,
 		isDiscard:  w == io.Discard,
 	}
 
@@ -81,11 +205,24 @@ func New(w io.Writer, options ...Option) *Logger {
 		option(logger)
 	}
 
+	// Cached so the level check can skip clamping and prefixLevels in the common case, see enabled
+	logger.levelRules = logger.clamped || logger.prefixLevels != nil
+
+	logger.RefreshColor()
+
+	if logger.hybridTime {
+		// After the options, which may set timeFunc
+		logger.start = logger.timeFunc()
+	}
+
 	return logger
 }
 
 // With returns a new [Logger] with the given persistent key value pairs.
 //
+// These are rendered before the per-call attrs of each log line, see [WithTrailingAttrs]
+// for attrs that should come after them instead.
+//
 // The returned logger is otherwise an exact clone of the caller.
 func (l *Logger) With(attrs ...slog.Attr) *Logger {
 	sub := l.clone()
@@ -95,6 +232,107 @@ func (l *Logger) With(attrs ...slog.Attr) *Logger {
 	return sub
 }
 
+// Attrs returns a copy of the persistent key value pairs attached to the logger
+// with [Logger.With], in the order they were added.
+//
+// The returned slice belongs to the caller, modifying it has no effect on the logger.
+func (l *Logger) Attrs() []slog.Attr {
+	return slices.Clone(l.attrs)
+}
+
+// Bare returns a new [Logger] sharing all of l's configuration but without its prefix
+// or persistent attrs (including those from [WithTrailingAttrs]), for the odd line that
+// shouldn't carry the logger's context, e.g. a banner:
+//
+//	logger.Bare().Info("All done!")
+//
+// l itself is unaffected. As the returned logger has no prefix, per-prefix levels and
+// muted prefixes don't apply to it.
+func (l *Logger) Bare() *Logger {
+	sub := l.clone()
+
+	sub.prefix = nil
+	sub.attrs = nil
+	sub.trailing = nil
+
+	return sub
+}
+
+// DebugAttrs writes a plain, human readable summary of the logger's own configuration
+// to w: its prefix, level and persistent attrs (including those from [WithTrailingAttrs]),
+// one per line in the order they were added. This is a troubleshooting aid for complex
+// trees of loggers built up with [Logger.With] and [Logger.Prefixed]:
+//
+//	logger.With(slog.String("service", "oven")).Prefixed("http").DebugAttrs(os.Stderr)
+//	// prefix: http
+//	// level:  INFO
+//	// attrs:
+//	//   service=oven
+//
+// The summary doesn't go through the normal log path, so it's written whatever the
+// logger's level, without colour and with no line hook. Errors writing to w are passed
+// to the logger's error handler, if any, see [WithErrorHandler].
+func (l *Logger) DebugAttrs(w io.Writer) {
+	buf := make([]byte, 0, bufferSize)
+
+	buf = append(buf, "prefix: "...)
+	if len(l.prefix) != 0 {
+		buf = append(buf, l.prefix...)
+	} else {
+		buf = append(buf, "(none)"...)
+	}
+
+	buf = l.appendEOL(buf)
+
+	buf = append(buf, "level:  "...)
+	buf = append(buf, l.level.text(l.levelCase)...)
+	buf = l.appendEOL(buf)
+
+	buf = l.appendDebugAttrs(buf, "attrs:", l.attrs)
+
+	if len(l.trailing) != 0 {
+		buf = l.appendDebugAttrs(buf, "trailing attrs:", l.trailing)
+	}
+
+	if _, err := w.Write(buf); err != nil && l.errorHandler != nil {
+		l.errorHandler(err)
+	}
+}
+
+// appendDebugAttrs appends a heading followed by attrs as indented, plain key=value
+// pairs, one per line, to dst for [Logger.DebugAttrs] and returns the extended slice.
+func (l *Logger) appendDebugAttrs(dst []byte, heading string, attrs []slog.Attr) []byte {
+	dst = append(dst, heading...)
+	if len(attrs) == 0 {
+		dst = append(dst, " (none)"...)
+	}
+
+	dst = l.appendEOL(dst)
+
+	for _, attr := range attrs {
+		dst = append(dst, "  "...)
+
+		if attr.Key == "" || needsQuotes(attr.Key) {
+			dst = l.appendQuoted(dst, attr.Key)
+		} else {
+			dst = append(dst, attr.Key...)
+		}
+
+		dst = append(dst, '=')
+
+		// Plain, even if durations are coloured with WithDurationThresholds
+		if value := attr.Value.Resolve(); value.Kind() == slog.KindDuration {
+			dst = append(dst, value.Duration().String()...)
+		} else {
+			dst = l.appendValue(dst, value)
+		}
+
+		dst = l.appendEOL(dst)
+	}
+
+	return dst
+}
+
 // Prefixed returns a new [Logger] with the given prefix.
 //
 // The returned logger is otherwise an exact clone of the caller.
@@ -106,6 +344,125 @@ func (l *Logger) Prefixed(prefix string) *Logger {
 	return sub
 }
 
+// To returns a new [Logger] writing to w rather than the caller's writer, with its own
+// lock (unless the caller is capturing its output, see [WithCapture]).
+//
+// Anything the caller detected from its own writer is detected afresh for w: whether to
+// colourise with [ColorDetect], whether to clear the line with [WithTerminalClearLine]
+// and the terminal width with WithWrapWidth(0).
+//
+// The returned logger is otherwise an exact clone of the caller, so shares its colour
+// mode, persistent attrs and so on.
+func (l *Logger) To(w io.Writer) *Logger {
+	sub := l.clone()
+
+	sub.w = w
+
+	if sub.captured == nil {
+		// A capturing logger never writes to w, and the lock also protects what it's captured
+		sub.mu = &sync.Mutex{}
+	}
+
+	sub.isDiscard = w == io.Discard && sub.captured == nil && sub.records == nil
+	sub.isTTY = &atomic.Bool{}
+	sub.RefreshColor()
+
+	if sub.autoClearLine {
+		sub.clearLine = isTerminal(w)
+	}
+
+	if sub.autoWrap {
+		sub.wrapWidth = terminalWidth(w)
+	}
+
+	return sub
+}
+
+// Named returns a new [Logger] whose prefix is the caller's prefix followed by name,
+// joined by the delimiter set with [WithDelimiter] ("." by default). This builds up a
+// hierarchy of prefixes for the parts of a program:
+//
+//	http := logger.Named("server").Named("http") // Prefix "server.http"
+//
+// If the caller has no prefix, name is used as is. The returned logger is otherwise an
+// exact clone of the caller.
+func (l *Logger) Named(name string) *Logger {
+	if len(l.prefix) == 0 {
+		return l.Prefixed(name)
+	}
+
+	return l.Prefixed(string(l.prefix) + l.delimiter + name)
+}
+
+// Prefixedf is like [Logger.Prefixed] but the prefix is built from a format
+// string and arguments, in the manner of [fmt.Sprintf].
+//
+//	worker := logger.Prefixedf("worker-%d", id)
+func (l *Logger) Prefixedf(format string, args ...any) *Logger {
+	return l.Prefixed(fmt.Sprintf(format, args...))
+}
+
+// SetColor changes whether the logger colourises its output, at any time, e.g. to let
+// users of an interactive program toggle colour. See [WithColor] for the modes.
+//
+// The change applies to the logger and every logger sharing its lineage, i.e. its parent
+// and any derived from it with [Logger.With], [Logger.Prefixed] etc. It is safe to call
+// concurrently with logging, though a line being rendered at the time may be only
+// partly coloured.
+func (l *Logger) SetColor(mode ColorMode) {
+	l.color.Store(int32(mode)) //nolint:gosec // ColorMode only has a handful of values
+}
+
+// Shadowed returns the number of times a persistent attr, added with [Logger.With], has
+// been left out of a log line because a per-call attr used the same key, since the logger
+// was created with [WithShadowWarnings]. The count is shared with every logger derived
+// from it.
+//
+// Loggers without [WithShadowWarnings] return 0.
+func (l *Logger) Shadowed() uint64 {
+	if l.shadowed == nil {
+		return 0
+	}
+
+	return l.shadowed.Load()
+}
+
+// Captured returns a copy of everything the logger (and any logger derived from it) has
+// written since it was created with [WithCapture], one entry per write with any trailing
+// newline removed. For ordinary log lines, that's one entry per line.
+//
+// Loggers not in capture mode return nil.
+func (l *Logger) Captured() []string {
+	if l.captured == nil {
+		return nil
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return slices.Clone(*l.captured)
+}
+
+// Merge returns a new [Logger] combining the persistent attrs and prefix of other
+// with the caller.
+//
+// The returned logger is a clone of the caller, keeping its writer, level and all
+// other configuration, but with other's persistent attrs appended after its own and
+// other's prefix in place of its own, if other has one. Nothing else is taken from other.
+//
+//	merged := app.Merge(library)
+func (l *Logger) Merge(other *Logger) *Logger {
+	sub := l.clone()
+
+	sub.attrs = slices.Concat(l.attrs, other.attrs)
+
+	if len(other.prefix) != 0 {
+		sub.prefix = other.prefix
+	}
+
+	return sub
+}
+
 // Debug writes a debug level log line.
 func (l *Logger) Debug(msg string, attrs ...slog.Attr) {
 	l.log(LevelDebug, msg, attrs...)
@@ -126,81 +483,971 @@ func (l *Logger) Error(msg string, attrs ...slog.Attr) {
 	l.log(LevelError, msg, attrs...)
 }
 
+// Log writes a log line at an arbitrary level, most useful for custom levels
+// registered with [RegisterLevel].
+func (l *Logger) Log(level Level, msg string, attrs ...slog.Attr) {
+	l.log(level, msg, attrs...)
+}
+
+// Render returns the log line that would be written for the given level, message
+// and attrs, including the trailing newline, without writing it anywhere.
+//
+// The line is rendered exactly as the logger would write it, respecting colour, format,
+// prefix and so on, but no level filtering is applied and the line hook is not called.
+// This makes it useful for embedding log lines in other output or for snapshot tests.
+func (l *Logger) Render(level Level, msg string, attrs ...slog.Attr) string {
+	var extra [extraAttrs]slog.Attr
+
+	rec := l.newRecord(l.timeFunc(), level, msg, attrs, extra[:0])
+
+	bufp := getBuffer(l.freeList, l.bufferHint)
+	defer putBuffer(l.freeList, bufp)
+
+	buf := l.render(*bufp, &rec)
+	*bufp = buf
+
+	return string(buf)
+}
+
+// Raw writes p to the logger's writer exactly as is, with no formatting and
+// regardless of level.
+//
+// This is useful for passing pre-rendered output (e.g. a banner or output from another
+// tool) through the logger so it's synchronised with log lines and never interleaved
+// with them. Nothing is written if the logger is discarding its output.
+func (l *Logger) Raw(p []byte) {
+	if l.isDiscard {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.writeLocked(p)
+}
+
+// Separator writes a blank line, or a horizontal rule if [WithSeparatorRune] is set,
+// to visually separate unrelated phases of a program's output.
+//
+// Like [Logger.Raw] it is written under the logger's lock regardless of level and
+// nothing is written if the logger is discarding its output. It does nothing for JSON
+// loggers so as not to break consumers of their output.
+func (l *Logger) Separator() {
+	if l.json {
+		return
+	}
+
+	if l.separator == 0 {
+		l.Raw(l.appendEOL(nil))
+		return
+	}
+
+	width := terminalWidth(l.w)
+	if width <= 0 {
+		width = separatorWidth
+	}
+
+	rule := make([]byte, 0, width*utf8.UTFMax)
+	for range width {
+		rule = utf8.AppendRune(rule, l.separator)
+	}
+
+	line := appendStyledBytes(nil, l.colorMode(), separatorStyle, rule)
+
+	l.Raw(l.appendEOL(line))
+}
+
+// Banner writes text as a prominent banner, e.g. to mark the start and end of a
+// program's output:
+//
+//	logger.Banner("mytool v1.2.3 starting")
+//
+// When writing to a terminal, text is shown in bold centred between two rules the
+// full width of the terminal, drawn with the rune from [WithSeparatorRune] if set.
+// Otherwise, or when the terminal's width can't be determined, text is written on
+// a line of its own.
+//
+// Like [Logger.Separator] it is written under the logger's lock regardless of level,
+// nothing is written if the logger is discarding its output and it does nothing
+// for JSON loggers.
+func (l *Logger) Banner(text string) {
+	if l.json {
+		return
+	}
+
+	mode := l.colorMode()
+
+	width := 0
+	if isTerminal(l.w) {
+		width = terminalWidth(l.w)
+	}
+
+	if width <= 0 {
+		line := appendStyled(nil, mode, bannerStyle, text)
+		l.Raw(l.appendEOL(line))
+
+		return
+	}
+
+	r := l.separator
+	if r == 0 {
+		r = bannerRune
+	}
+
+	rule := make([]byte, 0, width*utf8.UTFMax)
+	for range width {
+		rule = utf8.AppendRune(rule, r)
+	}
+
+	banner := make([]byte, 0, 2*len(rule)+len(text)+bufferSize)
+	banner = appendStyledBytes(banner, mode, separatorStyle, rule)
+	banner = l.appendEOL(banner)
+	banner = appendSpaces(banner, (width-displayWidth(text))/2)
+	banner = appendStyled(banner, mode, bannerStyle, text)
+	banner = l.appendEOL(banner)
+	banner = appendStyledBytes(banner, mode, separatorStyle, rule)
+	banner = l.appendEOL(banner)
+
+	l.Raw(banner)
+}
+
+// Track logs the start and end of an operation, measuring how long it took.
+//
+// msg is logged at debug level before fn is called. If fn returns nil, msg is logged
+// again at info level (or as configured by options, e.g. [WarnAbove]) along with a
+// "duration" attr, otherwise it is logged at error level with both the "duration" and
+// the "err". The error from fn is returned unchanged.
+//
+//	err := logger.Track("Downloading dependencies", func() error {
+//		return download(deps)
+//	})
+//
+// See [Logger.Timer] for timing an operation without wrapping it in a function.
+func (l *Logger) Track(msg string, fn func() error, options ...TimerOption) error {
+	var cfg timerConfig
+	for _, option := range options {
+		option(&cfg)
+	}
+
+	l.Debug(msg)
+
+	start := l.timeFunc()
+	err := fn()
+	elapsed := l.timeFunc().Sub(start)
+	duration := slog.Duration(durationKey, elapsed)
+
+	if err != nil {
+		l.Error(msg, duration, slog.Any("err", err))
+		return err
+	}
+
+	l.log(cfg.level(elapsed), msg, duration)
+
+	return nil
+}
+
+// Since returns a [slog.Attr] for the time elapsed since start, measured with the
+// logger's clock (see [TimeFunc]) so it's deterministic in tests.
+//
+//	start := time.Now()
+//	...
+//	logger.Info("Build finished", logger.Since("elapsed", start))
+func (l *Logger) Since(key string, start time.Time) slog.Attr {
+	return slog.Duration(key, l.timeFunc().Sub(start))
+}
+
+// Sync flushes any buffered log output through to its destination.
+//
+// If the logger's writer implements [Flusher] it is flushed first, then if it
+// implements [Syncer] it is synced, so a writer implementing both has its buffered
+// data pushed through before being committed to storage. Writers implementing neither
+// are left alone and Sync returns nil.
+//
+// Any errors from flushing or syncing are returned, joined together.
+func (l *Logger) Sync() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	var flushErr, syncErr error
+
+	if flusher, ok := l.w.(Flusher); ok {
+		flushErr = flusher.Flush()
+	}
+
+	if syncer, ok := l.w.(Syncer); ok {
+		syncErr = syncer.Sync()
+	}
+
+	return errors.Join(flushErr, syncErr)
+}
+
+// Recover logs any panic in progress at error level along with the stack trace, flushes
+// the logger with [Logger.Sync] and then panics again with the same value, so the crash
+// is recorded in the log before the program dies. It must be deferred directly:
+//
+//	func main() {
+//		logger := log.New(os.Stderr)
+//		defer logger.Recover()
+//		...
+//	}
+//
+// The panic is never swallowed. If there is no panic in progress, Recover does nothing.
+func (l *Logger) Recover() {
+	recovered := recover()
+	if recovered == nil {
+		return
+	}
+
+	l.Error("panic", slog.Any(panicKey, recovered), slog.String(stackKey, string(debug.Stack())))
+	l.Sync() //nolint:errcheck // Nothing useful to be done with the error, we're about to crash
+
+	panic(recovered)
+}
+
+// Enabled reports whether a log line at the given level would be shown by the logger.
+//
+// It can be used to guard expensive work only needed for logging:
+//
+//	if logger.Enabled(log.LevelDebug) {
+//		logger.Debug("State", slog.Any("state", expensiveDump()))
+//	}
+func (l *Logger) Enabled(level Level) bool {
+	return !l.isDiscard && l.enabled(level)
+}
+
+// DebugEnabled reports whether debug level log lines would be shown by the logger.
+func (l *Logger) DebugEnabled() bool {
+	return l.Enabled(LevelDebug)
+}
+
+// InfoEnabled reports whether info level log lines would be shown by the logger.
+func (l *Logger) InfoEnabled() bool {
+	return l.Enabled(LevelInfo)
+}
+
+// WarnEnabled reports whether warning level log lines would be shown by the logger.
+func (l *Logger) WarnEnabled() bool {
+	return l.Enabled(LevelWarn)
+}
+
+// ErrorEnabled reports whether error level log lines would be shown by the logger.
+func (l *Logger) ErrorEnabled() bool {
+	return l.Enabled(LevelError)
+}
+
+// LogAt logs a message at the given level like [Logger.Log], but timestamped t rather
+// than the current time. This is for re-emitting events that carry their own time, such
+// as when replaying historical events or ingesting them from elsewhere:
+//
+//	logger.LogAt(event.Time, log.LevelInfo, event.Message)
+//
+// The time is formatted exactly as the logger would format the current time, see
+// [TimeFormat]. With [WithHybridTime], the elapsed time shown is from the creation of
+// the logger to t.
+func (l *Logger) LogAt(t time.Time, level Level, msg string, attrs ...slog.Attr) {
+	if l.isDiscard || !l.enabled(level) {
+		return
+	}
+
+	l.writeAt(t, level, msg, attrs)
+}
+
 // log logs the given levelled message.
 func (l *Logger) log(level Level, msg string, attrs ...slog.Attr) {
-	if l.isDiscard || l.level > level {
+	if l.isDiscard || !l.enabled(level) {
 		// Do as little work as possible
 		return
 	}
 
+	l.write(level, msg, attrs)
+}
+
+// write renders and writes a log line, it does no level filtering of its own
+// so callers must check the level first.
+func (l *Logger) write(level Level, msg string, attrs []slog.Attr) {
+	l.writeAt(l.timeFunc(), level, msg, attrs)
+}
+
+// writeAt is [Logger.write] but with the line timestamped t, rather than the
+// current time.
+func (l *Logger) writeAt(t time.Time, level Level, msg string, attrs []slog.Attr) {
+	if !l.claimOnce() {
+		return
+	}
+
+	if l.records != nil {
+		l.send(t, level, msg, attrs)
+		return
+	}
+
+	// Attrs the logger adds itself, on the stack unless there are a lot of them
+	var extra [extraAttrs]slog.Attr
+
+	rec := l.newRecord(t, level, msg, attrs, extra[:0])
+
+	// Each output renders the record separately, so a LogValuer e.g. Lazy would
+	// otherwise be called once per output
+	if l.sinks != nil || l.jsonW != nil {
+		rec.resolve()
+	}
+
+	if l.sinks != nil {
+		l.writeSinks(&rec)
+		return
+	}
+
+	if l.jsonW != nil {
+		l.writeJSON(&rec)
+	}
+
+	if l.unbuffered {
+		l.writeUnbuffered(&rec)
+		return
+	}
+
 	// Build the line in a byte buffer fetched from a [sync.Pool] so we don't
 	// constantly allocate. Styled, known-ahead text (timestamp, level, prefix)
 	// is appended with hue's allocation-free AppendText.
-	bufp := getBuffer()
-	defer putBuffer(bufp)
+	bufp := getBuffer(l.freeList, l.bufferHint)
+	defer putBuffer(l.freeList, bufp)
 
 	// Dereference the working copy so we don't have to dereference every call
-	buf := *bufp
+	buf := l.render(*bufp, &rec)
+
+	// Put it back
+	*bufp = buf
+
+	l.emit(level, buf)
+}
+
+// emit writes a fully rendered log line (or lines) to w under the lock, calling
+// the line hook first if there is one.
+func (l *Logger) emit(level Level, line []byte) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.lineHook != nil {
+		l.lineHook(level, line)
+	}
+
+	l.writeLocked(line)
+}
+
+// writeLocked writes p to w, or keeps it in memory if the logger is capturing its
+// output (see [WithCapture]), and passes it to any readers from [Logger.Tail]. The
+// lock must be held.
+func (l *Logger) writeLocked(p []byte) {
+	l.tails.publish(p)
+
+	if l.captured != nil {
+		p = bytes.TrimSuffix(p, []byte(l.lineEnding()))
+
+		*l.captured = append(*l.captured, string(p))
+
+		return
+	}
+
+	// Just like printing, write errors are ignored unless the user has asked otherwise
+	if _, err := l.w.Write(p); err != nil && l.errorHandler != nil {
+		l.errorHandler(err)
+	}
+}
+
+// newRecord builds the record for a log line timestamped t, appending any attrs the logger
+// adds itself to extra, which should be an empty slice backed by an array on the caller's stack.
+func (l *Logger) newRecord(t time.Time, level Level, msg string, attrs, extra []slog.Attr) record {
+	persistent := l.attrs
+	if l.shadowed != nil {
+		persistent = l.unshadowed(attrs)
+	}
+
+	rec := record{
+		time:       t,
+		level:      level,
+		msg:        msg,
+		persistent: persistent,
+		attrs:      attrs,
+		trailing:   l.trailing,
+	}
+
+	if l.attrLayout != nil {
+		// The layout gets a copy so the caller's attrs don't escape to the heap
+		rec.persistent, rec.attrs = nil, l.attrLayout(persistent, slices.Clone(attrs))
+	}
+
+	if l.dynamicAttrs != nil {
+		rec.dynamic = l.dynamicAttrs()
+	}
+
+	if l.seq != nil {
+		extra = append(extra, slog.Uint64(seqKey, l.seq.Add(1)))
+	}
+
+	if l.goroutineID {
+		extra = append(extra, slog.Uint64(goroutineKey, goroutineID()))
+	}
+
+	if l.caller {
+		extra = append(extra, slog.String(l.callerKey, caller()))
+	}
+
+	rec.extra = extra
+
+	return rec
+}
+
+// unshadowed returns the logger's persistent attrs without any whose key is also used
+// by one of attrs, counting each one left out, see [WithShadowWarnings].
+func (l *Logger) unshadowed(attrs []slog.Attr) []slog.Attr {
+	var kept []slog.Attr
+
+	for i, persistent := range l.attrs {
+		shadowed := slices.ContainsFunc(attrs, func(attr slog.Attr) bool {
+			return attr.Key == persistent.Key
+		})
+
+		if !shadowed {
+			if kept != nil {
+				kept = append(kept, persistent)
+			}
+
+			continue
+		}
+
+		l.shadowed.Add(1)
+
+		if kept == nil {
+			// Only copy once something needs leaving out
+			kept = slices.Clone(l.attrs[:i:i])
+		}
+	}
+
+	if kept == nil {
+		return l.attrs
+	}
+
+	return kept
+}
+
+// writeUnbuffered writes rec to w component by component (timestamp, level, prefix,
+// message, each attr etc.) rather than in a single write, holding the lock throughout
+// so the line is still never interleaved with others.
+func (l *Logger) writeUnbuffered(rec *record) {
+	// The start of each component, the first always starts at 0
+	rec.marks = make([]int, maxComponents)
+	rec.nmarks = 1
+
+	buf := l.render(make([]byte, 0, bufferSize), rec)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.lineHook != nil {
+		l.lineHook(rec.level, buf)
+	}
+
+	if l.captured != nil {
+		// A captured line is kept whole
+		l.writeLocked(buf)
+		return
+	}
+
+	l.tails.publish(buf)
+
+	marks := rec.marks[:rec.nmarks]
+	for i, start := range marks {
+		end := len(buf)
+		if i+1 < len(marks) {
+			end = marks[i+1]
+		}
+
+		if start == end {
+			continue
+		}
+
+		if _, err := l.w.Write(buf[start:end]); err != nil && l.errorHandler != nil {
+			l.errorHandler(err)
+			return
+		}
+	}
+}
+
+// render appends the fully rendered form of rec to dst, in whichever format the
+// logger is configured to use, and returns the extended slice.
+func (l *Logger) render(dst []byte, rec *record) []byte {
+	if l.clearLine {
+		dst = append(dst, clearLine...)
+		rec.mark(len(dst))
+	}
+
+	if l.json {
+		return l.appendJSON(dst, rec)
+	}
+
+	return l.appendText(dst, rec)
+}
+
+// enabled reports whether level passes both the global minimum level, if set, and
+// the logger's own level.
+func (l *Logger) enabled(level Level) bool {
+	if l.levelRules || levelOverridden.Load() {
+		return l.enabledByRules(level)
+	}
+
+	// The common case, only the logger's own level applies
+	return level >= l.level
+}
+
+// enabledByRules is [Logger.enabled] for a logger with level rules or when levels have
+// been overridden at runtime, kept separate so enabled is cheap enough to be inlined.
+func (l *Logger) enabledByRules(level Level) bool {
+	return l.enabledAt(level, l.minLevel())
+}
+
+// minLevel returns the minimum level for the logger, taking into account any
+// per-prefix level set with [WithPrefixLevel].
+func (l *Logger) minLevel() Level {
+	if l.prefixLevels != nil {
+		// The compiler optimises away the string conversion in a map lookup
+		if level, ok := l.prefixLevels[string(l.prefix)]; ok {
+			return level
+		}
+	}
+
+	return l.level
+}
+
+// enabledAt reports whether level passes both the global minimum level, if set, and
+// the given minimum level (clamped if need be, see [WithClampLevel]), and the logger's
+// prefix isn't muted.
+func (l *Logger) enabledAt(level, minimum Level) bool {
+	if floor := globalMinLevel.Load(); floor != nil && *floor > level {
+		return false
+	}
+
+	if l.clamped {
+		minimum = min(max(minimum, l.clampMin), l.clampMax)
+	}
+
+	return level >= minimum && !l.muted.contains(l.prefix)
+}
+
+// record is a single log line, prior to rendering.
+type record struct {
+	time       time.Time   // The time of the log line
+	msg        string      // The log message
+	persistent []slog.Attr // The logger's persistent attrs, rendered first
+	attrs      []slog.Attr // The attrs passed to the log call, rendered after the persistent ones
+	dynamic    []slog.Attr // The attrs from the logger's dynamic attrs function, rendered after the per-call ones
+	trailing   []slog.Attr // The logger's trailing attrs, rendered after the dynamic ones
+	extra      []slog.Attr // Attrs added by the logger itself e.g. goroutine ID, always rendered last
+	marks      []int       // Offsets of the start of each rendered component, only tracked if non-nil
+	level      Level       // The level of the log line
+	nmarks     int         // The number of entries of marks in use
+}
+
+// mark records that a new rendered component starts at offset n, if the record is
+// tracking components. Once marks is full, any further components are merged into
+// the last one.
+//
+// This deliberately only ever stores ints into marks rather than appending to it, so
+// the record itself doesn't escape to the heap.
+func (r *record) mark(n int) {
+	if r.nmarks > 0 && r.marks[r.nmarks-1] == n {
+		// Nothing was rendered since the last mark e.g. an omitted attr
+		return
+	}
+
+	if r.nmarks < len(r.marks) {
+		r.marks[r.nmarks] = n
+		r.nmarks++
+	}
+}
+
+// resolve resolves any [slog.LogValuer] attrs of the record so they're called just the
+// once however many times it's rendered. Attrs are copied before being changed as they're
+// shared with the caller and the logger.
+func (r *record) resolve() {
+	for _, group := range [...]*[]slog.Attr{&r.persistent, &r.attrs, &r.dynamic, &r.trailing} {
+		resolved := false
+
+		for i, attr := range *group {
+			if attr.Value.Kind() != slog.KindLogValuer {
+				continue
+			}
+
+			if !resolved {
+				*group = slices.Clone(*group)
+				resolved = true
+			}
+
+			(*group)[i].Value = attr.Value.Resolve()
+		}
+	}
+}
+
+// appendText appends the human readable text form of a log line to dst and
+// returns the extended slice.
+//
+// The persistent attrs are rendered first, followed by the per-call attrs, any dynamic
+// attrs, the trailing attrs and finally any extra attrs added by the logger, up to the limit
+// set by [WithMaxAttrs].
+func (l *Logger) appendText(dst []byte, rec *record) []byte {
+	start := len(dst)
 
 	// Format the timestamp into a stack scratch buffer so we avoid allocating
 	// an intermediate string before styling it.
 	var scratch [scratchSize]byte
 
-	timestamp := l.timeFunc().AppendFormat(scratch[:0], l.timeFormat)
-	buf = timestampStyle.AppendText(buf, timestamp)
+	if !l.noTimestamp && !rec.time.IsZero() {
+		var timestamp []byte
+		if l.hybridTime && rec.level < LevelWarn {
+			timestamp = l.appendElapsed(scratch[:0], rec.time)
+		} else {
+			timestamp = l.appendTimestamp(scratch[:0], rec.time)
+		}
 
-	buf = append(buf, ' ')
-	buf = level.appendTo(buf)
+		dst = appendStyledBytes(dst, l.colorMode(), timestampStyle, timestamp)
+		rec.mark(len(dst))
+	}
 
-	if len(l.prefix) != 0 {
-		buf = append(buf, ' ')
-		buf = prefixStyle.AppendText(buf, l.prefix)
+	if !l.noLevel {
+		dst = appendGap(dst, start)
+		if l.compactLevels {
+			dst = rec.level.appendBadge(dst, l.colorMode(), l.levelCase)
+		} else {
+			dst = rec.level.appendTo(dst, l.colorMode(), l.levelCase)
+		}
+
+		// An explicit level width pads the label itself, so prefixes line up too
+		if l.levelWidth > 0 {
+			dst = appendSpaces(dst, l.labelPadding(rec.level, l.levelWidth))
+		}
+
+		if tag, ok := l.levelPrefixes[rec.level]; ok && tag != "" {
+			dst = append(dst, ' ')
+			dst = appendStyled(dst, l.colorMode(), rec.level.style(), tag)
+		}
+
+		rec.mark(len(dst))
 	}
 
-	buf = append(buf, ':')
+	prefix := l.prefix
+	if len(prefix) == 0 {
+		prefix = l.service
+	}
 
-	// DEBUG and ERROR are 5 characters, INFO and WARN are 4. Pad the shorter
-	// labels with an extra space so the message always starts in the same column.
-	buf = append(buf, ' ')
-	if level == LevelInfo || level == LevelWarn {
-		buf = append(buf, ' ')
+	if l.messageColumn > 0 && len(prefix) != 0 {
+		// Leave room for the gap before the prefix and the ": " after it. If the column
+		// can't be reached anyway, the prefix is left whole rather than lost
+		if room := l.messageColumn - displayWidth(dst[start:]) - len(" : "); room > 0 {
+			prefix = truncateWidth(prefix, room)
+		}
 	}
 
-	buf = append(buf, msg...)
+	if len(prefix) != 0 {
+		style := prefixStyle
+		if l.prefixByLevel {
+			style = rec.level.style()
+		}
 
-	for _, attr := range l.attrs {
-		buf = appendAttr(buf, attr)
+		dst = appendGap(dst, start)
+		dst = appendStyledBytes(dst, l.colorMode(), style, prefix)
+		rec.mark(len(dst))
 	}
 
-	for _, attr := range attrs {
-		buf = appendAttr(buf, attr)
+	// With no timestamp, level or prefix there's nothing for the colon to follow
+	if len(dst) > start {
+		if l.punctuation != 0 {
+			dst = appendStyled(dst, l.colorMode(), l.punctuation, ":")
+			dst = append(dst, ' ')
+		} else {
+			dst = append(dst, ':', ' ')
+		}
 	}
 
-	buf = append(buf, '\n')
+	// By default, pad shorter labels after the colon to the width of the longest
+	// built in label (DEBUG and ERROR) so the message always starts in the same
+	// column. Without a label, or with badges which are all the same width, there
+	// is nothing to line up.
+	//
+	// A message column instead pads the whole header out to that column.
+	switch {
+	case l.messageColumn > 0:
+		dst = appendSpaces(dst, l.messageColumn-displayWidth(dst[start:]))
+	case !l.noLevel && !l.compactLevels && l.levelWidth <= 0:
+		dst = appendSpaces(dst, rec.level.padding(labelWidth))
+	}
 
-	// Put it back
-	*bufp = buf
+	msgStart := len(dst)
 
-	l.mu.Lock()
-	defer l.mu.Unlock()
+	if l.msgAttr {
+		dst = appendStyled(dst, l.colorMode(), keyStyle, messageKey)
+		dst = append(dst, '=')
+
+		// Quoted just like a string attr value would be
+		if rec.msg == "" || needsQuotes(rec.msg) {
+			dst = l.appendQuoted(dst, rec.msg)
+		} else {
+			dst = append(dst, rec.msg...)
+		}
+	} else {
+		dst = append(dst, rec.msg...)
+	}
+
+	var wrap wrapper
+	if l.wrapWidth > 0 {
+		indent := displayWidth(dst[start:msgStart])
+		wrap = wrapper{
+			width:  l.wrapWidth,
+			indent: indent,
+			used:   indent + displayWidth(dst[msgStart:]),
+			eol:    l.lineEnding(),
+		}
+	}
+
+	rec.mark(len(dst))
+
+	rendered, total := 0, 0
+
+	// Without a message the first attr takes its place, rather than leaving a gap
+	trim := rec.msg == "" && !l.msgAttr
+
+	for i, group := range [...][]slog.Attr{rec.persistent, rec.attrs, rec.dynamic, rec.trailing, rec.extra} {
+		total += len(group)
 
-	l.w.Write(buf) //nolint: errcheck // Just like printing
+		// The persistent attrs are always the first group
+		section := l.attrSections && i == 0
+		open := false
+
+		for j, attr := range group {
+			if l.maxAttrs > 0 && rendered == l.maxAttrs {
+				break
+			}
+
+			attrStart := len(dst)
+			dst = l.appendAttr(dst, attr)
+			rendered++
+
+			// Where the attr starts, after its leading space
+			lead := attrStart + 1
+
+			if trim && len(dst) > attrStart {
+				dst = slices.Delete(dst, attrStart, lead)
+				lead = attrStart
+				trim = false
+			}
+
+			if section {
+				// Open the section inside the first attr that rendered anything, after
+				// its leading space, and close it after the last one, so the brackets
+				// wrap along with them
+				if !open && len(dst) > attrStart {
+					dst = l.openSection(dst, lead)
+					open = true
+				}
+
+				if open && (j == len(group)-1 || rendered == l.maxAttrs) {
+					dst = appendStyled(dst, l.colorMode(), sectionStyle, "]")
+					open = false
+				}
+			}
+
+			dst = wrap.wrap(dst, attrStart)
+			rec.mark(len(dst))
+		}
+
+		// Only if the last attr in the section rendered nothing, e.g. a false bool
+		// with WithFlagStyleBools
+		if open {
+			dst = appendStyled(dst, l.colorMode(), sectionStyle, "]")
+			rec.mark(len(dst))
+		}
+	}
+
+	if more := total - rendered; more > 0 {
+		attrStart := len(dst)
+		dst = append(dst, ' ')
+		dst = l.appendMore(dst, more)
+		dst = wrap.wrap(dst, attrStart)
+		rec.mark(len(dst))
+	}
+
+	return l.appendEOL(dst)
+}
+
+// openSection inserts the opening bracket of the persistent attrs section into dst
+// at index at, see [WithAttrSections], and returns the extended slice.
+func (l *Logger) openSection(dst []byte, at int) []byte {
+	var scratch [scratchSize]byte
+
+	return slices.Insert(dst, at, appendStyled(scratch[:0], l.colorMode(), sectionStyle, "[")...)
+}
+
+// appendGap appends the space between components of the line header to dst, unless
+// nothing has been written since start, and returns the extended slice.
+func appendGap(dst []byte, start int) []byte {
+	if len(dst) > start {
+		dst = append(dst, ' ')
+	}
+
+	return dst
+}
+
+// labelPadding returns the number of spaces needed after the level's label (or badge,
+// see [WithCompactLevels]) to pad it to width runes.
+func (l *Logger) labelPadding(level Level, width int) int {
+	if l.compactLevels {
+		return max(width-1, 0)
+	}
+
+	return level.padding(width)
+}
+
+// appendMore appends the marker for n attrs left out by [WithMaxAttrs] to dst and
+// returns the extended slice.
+func (l *Logger) appendMore(dst []byte, n int) []byte {
+	var scratch [scratchSize]byte
+
+	marker := append(scratch[:0], "…(+"...)
+	marker = strconv.AppendInt(marker, int64(n), base10)
+	marker = append(marker, " more)"...)
+
+	return appendStyledBytes(dst, l.colorMode(), moreStyle, marker)
+}
+
+// wrapper breaks a text log line between attrs so that no line exceeds a given
+// display width, if it can help it. A single attr is never split across lines.
+//
+// The zero value does no wrapping.
+type wrapper struct {
+	eol    string // The line ending, see WithLineEnding
+	width  int    // The maximum display width of a line, 0 disables wrapping
+	indent int    // The display width continuation lines are indented by, so they sit under the message
+	used   int    // The display width used so far on the current line
+}
+
+// wrap is called after appending an attr (with its leading space) that starts at
+// attrStart in dst. If the attr would take the current line past the width limit
+// it's moved onto a new, indented, continuation line.
+func (w *wrapper) wrap(dst []byte, attrStart int) []byte {
+	if w.width <= 0 {
+		return dst
+	}
+
+	attrWidth := displayWidth(dst[attrStart:])
+
+	// If the attr is the first thing on a continuation line and still doesn't fit
+	// there's nothing we can do, moving it would only leave an empty line behind.
+	if w.used+attrWidth <= w.width || w.used == w.indent {
+		w.used += attrWidth
+		return dst
+	}
+
+	// Swap the attr's leading space for a line ending and the indent, shuffling
+	// the attr along in place to make room.
+	end := len(dst)
+	extra := len(w.eol) - 1 + w.indent
+	dst = slices.Grow(dst, extra)[:end+extra]
+	copy(dst[attrStart+1+extra:], dst[attrStart+1:end])
+
+	n := copy(dst[attrStart:], w.eol)
+	for i := range w.indent {
+		dst[attrStart+n+i] = ' '
+	}
+
+	// attrWidth included the leading space we've just replaced
+	w.used = w.indent + attrWidth - 1
+
+	return dst
 }
 
 // appendAttr appends a single " key=value" pair to dst and returns the
 // extended slice. The key is quoted if it contains whitespace or is empty.
-func appendAttr(dst []byte, attr slog.Attr) []byte {
+//
+// Multi-errors are expanded into one pair per wrapped error, see [Err], changes
+// are rendered as " key: old → new", see [Logger.Change], and intervals as
+// " key=start→end (duration)", see [Interval].
+func (l *Logger) appendAttr(dst []byte, attr slog.Attr) []byte {
+	// Resolve once up front so a [slog.LogValuer] isn't called again for each check below.
+	// Neither Resolve nor Kind are free, hence only calling them when needed
+	kind := attr.Value.Kind()
+	if kind == slog.KindLogValuer {
+		attr.Value = attr.Value.Resolve()
+		kind = attr.Value.Kind()
+	}
+
+	if kind == slog.KindAny {
+		if errs, ok := joinedErrors(attr.Value); ok {
+			for _, expanded := range expandErrors(attr.Key, errs) {
+				dst = l.appendAttr(dst, expanded)
+			}
+
+			return dst
+		}
+
+		switch value := attr.Value.Any().(type) {
+		case change:
+			return l.appendChange(dst, attr.Key, value)
+		case interval:
+			return l.appendInterval(dst, attr.Key, value)
+		}
+	}
+
+	if l.flagBools && kind == slog.KindBool {
+		if !attr.Value.Bool() {
+			return dst
+		}
+
+		return l.appendKey(dst, attr.Key)
+	}
+
+	dst = l.appendKey(dst, attr.Key)
+	dst = append(dst, '=')
+
+	start := len(dst)
+	dst = l.appendValue(dst, attr.Value)
+
+	if l.unitHints != nil {
+		dst = l.appendUnit(dst, attr)
+	}
+
+	if l.valueColor != nil {
+		if style, ok := l.valueColor(attr.Key, attr.Value); ok {
+			// Restyle the plain value in place, the copy is needed as styling
+			// writes over where the plain text currently is
+			text := slices.Clone(dst[start:])
+
+			return appendStyledBytes(dst[:start], l.colorMode(), style, text)
+		}
+	}
+
+	return dst
+}
+
+// appendKey appends " key" to dst and returns the extended slice, quoting the key
+// if it contains whitespace or is empty.
+func (l *Logger) appendKey(dst []byte, key string) []byte {
 	dst = append(dst, ' ')
 
-	key := attr.Key
 	if key == "" || needsQuotes(key) {
-		key = strconv.Quote(key)
+		var scratch [scratchSize]byte
+
+		return appendStyledBytes(dst, l.colorMode(), keyStyle, l.appendQuoted(scratch[:0], key))
 	}
 
-	dst = keyStyle.AppendString(dst, key)
-	dst = append(dst, '=')
+	return appendStyled(dst, l.colorMode(), keyStyle, key)
+}
 
-	return appendValue(dst, attr.Value)
+// appendUnit appends the unit hint for attr's key to dst if there is one and attr
+// holds a number, returning the extended slice.
+func (l *Logger) appendUnit(dst []byte, attr slog.Attr) []byte {
+	switch attr.Value.Kind() {
+	case slog.KindInt64, slog.KindUint64, slog.KindFloat64:
+		return append(dst, l.unitHints[attr.Key]...)
+	default:
+		return dst
+	}
 }
 
 // appendValue appends the textual form of v to dst and returns the extended slice.
@@ -208,15 +1455,23 @@ func appendAttr(dst []byte, attr slog.Attr) []byte {
 // Scalar kinds are written straight into the buffer, skipping the "needs quotes" check
 // as their text can never contain whitespace and so are never quoted.
 //
-// Other kinds fall back to [slog.Value.String], quoted if they contain whitespace or are empty.
-func appendValue(dst []byte, v slog.Value) []byte {
+// Durations are likewise never quoted, and are coloured by magnitude if duration
+// thresholds are configured.
+//
+// A [json.RawMessage] or [json.Marshaler] is rendered as compact JSON, unquoted. Values
+// implementing [fmt.Stringer] are rendered with their String method, even if they
+// are lists (see [WithListFormat]). Other kinds fall back to [slog.Value.String]. Either
+// way they're quoted if they contain whitespace or are empty.
+func (l *Logger) appendValue(dst []byte, v slog.Value) []byte {
 	// Resolve any [slog.LogValuer]
 	// See https://github.com/golang/example/blob/master/slog-handler-guide/README.md
-	if v.Kind() == slog.KindLogValuer {
+	kind := v.Kind()
+	if kind == slog.KindLogValuer {
 		v = v.Resolve()
+		kind = v.Kind()
 	}
 
-	switch v.Kind() {
+	switch kind {
 	case slog.KindInt64:
 		return strconv.AppendInt(dst, v.Int64(), base10)
 	case slog.KindUint64:
@@ -225,27 +1480,191 @@ func appendValue(dst []byte, v slog.Value) []byte {
 		return strconv.AppendFloat(dst, v.Float64(), 'g', -1, float64Bits)
 	case slog.KindBool:
 		return strconv.AppendBool(dst, v.Bool())
+	case slog.KindDuration:
+		if l.slowDuration > 0 {
+			return appendStyled(dst, l.colorMode(), l.durationStyle(v.Duration()), v.Duration().String())
+		}
+
+		return append(dst, v.Duration().String()...)
+	case slog.KindString:
+		// By far the most common, so it skips the checks below that only apply to KindAny
+		return l.appendString(dst, v.String())
 	default:
-		s := v.String()
-		if s == "" || needsQuotes(s) {
-			return strconv.AppendQuote(dst, s)
+		var s string
+		if raw, ok := asRawJSON(v); ok {
+			return l.appendRawJSON(dst, raw)
+		} else if stringer, ok := asStringer(v); ok {
+			s = stringer.String()
+		} else if list, ok := l.formatList(v); ok {
+			s = list
+		} else {
+			s = v.String()
+		}
+
+		return l.appendString(dst, s)
+	}
+}
+
+// appendString appends the text of a value to dst, quoted if it contains whitespace or
+// is empty, and returns the extended slice.
+func (l *Logger) appendString(dst []byte, s string) []byte {
+	if s == "" || needsQuotes(s) {
+		if l.smartPaths && isPathLike(s) {
+			return appendPercentEncoded(dst, s)
 		}
 
-		return append(dst, s...)
+		return l.appendQuoted(dst, s)
+	}
+
+	return append(dst, s...)
+}
+
+// lineEnding returns the logger's line ending, see [WithLineEnding].
+func (l *Logger) lineEnding() string {
+	if l.crlf {
+		return crlf
+	}
+
+	return lf
+}
+
+// appendEOL appends the logger's line ending to dst and returns the extended slice.
+func (l *Logger) appendEOL(dst []byte) []byte {
+	if l.crlf {
+		return append(dst, '\r', '\n')
+	}
+
+	return append(dst, '\n')
+}
+
+// appendSpaces appends n spaces to dst and returns the extended slice.
+func appendSpaces(dst []byte, n int) []byte {
+	for range n {
+		dst = append(dst, ' ')
+	}
+
+	return dst
+}
+
+// asStringer returns the value held by v as a [fmt.Stringer] if it is one, and isn't
+// a nil pointer whose String method could panic.
+func asStringer(v slog.Value) (fmt.Stringer, bool) {
+	if v.Kind() != slog.KindAny {
+		return nil, false
+	}
+
+	stringer, ok := v.Any().(fmt.Stringer)
+	if !ok {
+		return nil, false
+	}
+
+	if val := reflect.ValueOf(stringer); val.Kind() == reflect.Pointer && val.IsNil() {
+		return nil, false
+	}
+
+	return stringer, true
+}
+
+// colorMode returns the logger's current colour mode, resolving [ColorDetect] to
+// [ColorAlways] or [ColorNever].
+func (l *Logger) colorMode() ColorMode {
+	mode := ColorMode(l.color.Load())
+	if mode != ColorDetect {
+		return mode
+	}
+
+	if l.isTTY.Load() {
+		return ColorAlways
+	}
+
+	return ColorNever
+}
+
+// durationStyle returns the style for a duration value based on the logger's
+// configured duration thresholds.
+func (l *Logger) durationStyle(d time.Duration) hue.Style {
+	switch {
+	case d < l.fastDuration:
+		return fastStyle
+	case d < l.slowDuration:
+		return mediumStyle
+	default:
+		return slowStyle
 	}
 }
 
 // clone returns an exact clone of the calling logger.
 func (l *Logger) clone() *Logger {
 	clone := &Logger{
-		w:          l.w,
-		timeFunc:   l.timeFunc,
-		timeFormat: l.timeFormat,
-		prefix:     l.prefix,
-		attrs:      l.attrs,
-		level:      l.level,
-		mu:         l.mu,
-		isDiscard:  l.isDiscard,
+		w:             l.w,
+		timeFunc:      l.timeFunc,
+		start:         l.start,
+		hybridTime:    l.hybridTime,
+		attrLayout:    l.attrLayout,
+		timeFormat:    l.timeFormat,
+		prefix:        l.prefix,
+		attrs:         l.attrs,
+		level:         l.level,
+		clampMin:      l.clampMin,
+		clampMax:      l.clampMax,
+		clamped:       l.clamped,
+		fastDuration:  l.fastDuration,
+		slowDuration:  l.slowDuration,
+		wrapWidth:     l.wrapWidth,
+		mu:            l.mu,
+		jsonW:         l.jsonW,
+		jsonMu:        l.jsonMu,
+		sinks:         l.sinks,
+		isDiscard:     l.isDiscard,
+		noLevel:       l.noLevel,
+		json:          l.json,
+		jsonPretty:    l.jsonPretty,
+		lineHook:      l.lineHook,
+		clearLine:     l.clearLine,
+		autoClearLine: l.autoClearLine,
+		autoWrap:      l.autoWrap,
+		crlf:          l.crlf,
+		listFormat:    l.listFormat,
+		errorHandler:  l.errorHandler,
+		color:         l.color,
+		isTTY:         l.isTTY,
+		muted:         l.muted,
+		tails:         l.tails,
+		goroutineID:   l.goroutineID,
+		caller:        l.caller,
+		callerKey:     l.callerKey,
+		unbuffered:    l.unbuffered,
+		prefixLevels:  l.prefixLevels,
+		levelWidth:    l.levelWidth,
+		trailing:      l.trailing,
+		valueColor:    l.valueColor,
+		timePreset:    l.timePreset,
+		separator:     l.separator,
+		quote:         l.quote,
+		levelPrefixes: l.levelPrefixes,
+		records:       l.records,
+		unitHints:     l.unitHints,
+		flagBools:     l.flagBools,
+		smartPaths:    l.smartPaths,
+		msgAttr:       l.msgAttr,
+		attrSections:  l.attrSections,
+		bufferHint:    l.bufferHint,
+		freeList:      l.freeList,
+		levelCase:     l.levelCase,
+		punctuation:   l.punctuation,
+		captured:      l.captured,
+		maxAttrs:      l.maxAttrs,
+		delimiter:     l.delimiter,
+		service:       l.service,
+		compactLevels: l.compactLevels,
+		prefixByLevel: l.prefixByLevel,
+		seq:           l.seq,
+		shadowed:      l.shadowed,
+		noTimestamp:   l.noTimestamp,
+		dynamicAttrs:  l.dynamicAttrs,
+		messageColumn: l.messageColumn,
+		once:          l.once,
+		levelRules:    l.levelRules,
 	}
 
 	return clone
@@ -263,31 +1682,55 @@ var bufPool = sync.Pool{
 	},
 }
 
-// getBuffer fetches a buffer from the pool, the returned buffer
-// is empty and ready to use.
-func getBuffer() *[]byte {
-	bufp := bufPool.Get().(*[]byte) //nolint:errcheck,forcetypeassert // We are in total control of this
-	*bufp = (*bufp)[:0]             // Reset
+// getBuffer fetches a buffer from freeList, or the pool if freeList is nil (see
+// [WithBufferStrategy]). The returned buffer is empty and ready to use, with a capacity
+// of at least hint bytes (up to maxBufferSize).
+func getBuffer(freeList chan *[]byte, hint int) *[]byte {
+	var bufp *[]byte
+
+	if freeList == nil {
+		bufp = bufPool.Get().(*[]byte) //nolint:errcheck,forcetypeassert // We are in total control of this
+	} else {
+		select {
+		case bufp = <-freeList:
+		default:
+			// All in use, the free list caps how many are kept not how many exist
+			buf := make([]byte, 0, bufferSize)
+			bufp = &buf
+		}
+	}
+
+	*bufp = (*bufp)[:0] // Reset
+
+	if hint > cap(*bufp) {
+		*bufp = slices.Grow(*bufp, min(hint, maxBufferSize))
+	}
 
 	return bufp
 }
 
-// putBuffer puts the buffer back into the pool.
-func putBuffer(bufp *[]byte) {
+// putBuffer puts the buffer back into freeList, or the pool if freeList is nil. If
+// freeList is full, the buffer is left for the garbage collector.
+func putBuffer(freeList chan *[]byte, bufp *[]byte) {
 	// Proper usage of a sync.Pool requires each entry to have approximately
 	// the same memory cost. To obtain this property when the stored type
 	// contains a variably-sized buffer, we add a hard limit on the maximum buffer
 	// to place back in the pool.
 	//
 	// See https://go.dev/issue/23199
+	if cap(*bufp) > maxBufferSize {
+		return
+	}
 
-	// Approx 65kb
-	const maxSize = 64 << 10
-	if cap(*bufp) > maxSize {
+	if freeList == nil {
+		bufPool.Put(bufp)
 		return
 	}
 
-	bufPool.Put(bufp)
+	select {
+	case freeList <- bufp:
+	default:
+	}
 }
 
 // needsQuotes returns whether s should be displayed as "s".
@@ -2,11 +2,18 @@ package log_test
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"math"
 	"net/http"
 	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"testing"
@@ -239,6 +246,28 @@ func TestWith(t *testing.T) {
 			},
 			want: "[TIME] INFO svc:  prefixed a=1\n",
 		},
+		{
+			name: "Prefixedf formats the prefix",
+			fn: func() string {
+				buf := &bytes.Buffer{}
+				l := log.New(buf, log.TimeFunc(fixedTime))
+				l.Prefixedf("worker-%d", 3).Info("working")
+
+				return buf.String()
+			},
+			want: "[TIME] INFO worker-3:  working\n",
+		},
+		{
+			name: "Prefixedf special characters",
+			fn: func() string {
+				buf := &bytes.Buffer{}
+				l := log.New(buf, log.TimeFunc(fixedTime))
+				l.Prefixedf("%s/%q", "100%", "job").Info("working")
+
+				return buf.String()
+			},
+			want: "[TIME] INFO 100%/\"job\":  working\n",
+		},
 		{
 			name: "parent logger not affected by child With",
 			fn: func() string {
@@ -247,25 +276,2596 @@ func TestWith(t *testing.T) {
 				_ = l.With(slog.String("child", "attr"))
 				l.Info("parent should have no attrs")
 
-				return buf.String()
-			},
-			want: "[TIME] INFO:  parent should have no attrs\n",
+				return buf.String()
+			},
+			want: "[TIME] INFO:  parent should have no attrs\n",
+		},
+		{
+			name: "Bare drops attrs and prefix",
+			fn: func() string {
+				buf := &bytes.Buffer{}
+				l := log.New(buf, log.TimeFunc(fixedTime), log.WithTrailingAttrs(slog.Int("pid", 1))).
+					With(slog.String("a", "1")).
+					Prefixed("svc")
+				l.Bare().Info("bare", slog.String("b", "2"))
+				l.Info("parent")
+
+				return buf.String()
+			},
+			want: "[TIME] INFO:  bare b=2\n[TIME] INFO svc:  parent a=1 pid=1\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.fn()
+			got = strings.ReplaceAll(got, fixedTimeString, "[TIME]")
+			test.Diff(t, got, tt.want)
+		})
+	}
+}
+
+func TestShadowWarnings(t *testing.T) {
+	hue.Enabled(false) // Force no color
+
+	buf := &bytes.Buffer{}
+	logger := log.New(buf, log.WithPlain(), log.WithShadowWarnings()).
+		With(slog.String("user", "a"), slog.String("request", "1"), slog.String("region", "eu"))
+
+	logger.Info("Not shadowed", slog.String("other", "x"))
+	test.Equal(t, logger.Shadowed(), 0)
+
+	logger.Info("Shadowed", slog.String("region", "us"), slog.String("user", "b"))
+	test.Equal(t, logger.Shadowed(), 2)
+
+	logger.With(slog.Int("extra", 1)).Info("Child", slog.String("request", "2"))
+	test.Equal(t, logger.Shadowed(), 3, test.Context("count should be shared with child loggers"))
+
+	want := "INFO:  Not shadowed user=a request=1 region=eu other=x\n" +
+		"INFO:  Shadowed request=1 region=us user=b\n" +
+		"INFO:  Child user=a region=eu extra=1 request=2\n"
+
+	test.Diff(t, buf.String(), want)
+
+	// Without the option, both are shown and nothing is counted
+	buf.Reset()
+
+	plain := log.New(buf, log.WithPlain()).With(slog.String("user", "a"))
+	plain.Info("Both", slog.String("user", "b"))
+
+	test.Equal(t, plain.Shadowed(), 0)
+	test.Diff(t, buf.String(), "INFO:  Both user=a user=b\n")
+}
+
+func TestMerge(t *testing.T) {
+	hue.Enabled(false)
+
+	fixedTime := func() time.Time {
+		fixed, err := time.Parse(time.RFC3339, "2025-04-01T13:34:03Z")
+		test.Ok(t, err)
+
+		return fixed
+	}
+
+	buf := &bytes.Buffer{}
+	app := log.New(buf, log.TimeFunc(fixedTime), log.WithLevel(log.LevelDebug), log.Prefix("app")).
+		With(slog.String("version", "1.2.3"))
+
+	// Configured differently, only its prefix and attrs should be used
+	library := log.New(io.Discard, log.WithLevel(log.LevelError), log.Prefix("lib")).With(slog.String("component", "cache"))
+
+	app.Merge(library).Debug("Merged", slog.Int("hits", 3))
+
+	// Without a prefix, the caller's is kept
+	app.Merge(log.New(io.Discard).With(slog.Bool("extra", true))).Info("No prefix")
+
+	want := "2025-04-01T13:34:03Z DEBUG lib: Merged version=1.2.3 component=cache hits=3\n" +
+		"2025-04-01T13:34:03Z INFO app:  No prefix version=1.2.3 extra=true\n"
+
+	test.Diff(t, buf.String(), want)
+
+	// Neither original is changed
+	test.Equal(t, len(app.Attrs()), 1)
+	test.Equal(t, len(library.Attrs()), 1)
+}
+
+func TestWithoutLevelLabel(t *testing.T) {
+	hue.Enabled(false) // Force no color
+
+	// Constantly return the same time
+	fixedTime := func() time.Time {
+		fixed, err := time.Parse(time.RFC3339, "2025-04-01T13:34:03Z")
+		test.Ok(t, err)
+
+		return fixed
+	}
+
+	fixedTimeString := fixedTime().Format(time.RFC3339)
+
+	tests := []struct {
+		name   string // Name of the test case
+		prefix string // Optional prefix for the logger
+		want   string // Expected log output
+		level  log.Level
+	}{
+		{name: "debug", level: log.LevelDebug, want: "[TIME]: message key=value\n"},
+		{name: "info", level: log.LevelInfo, want: "[TIME]: message key=value\n"},
+		{name: "warn", level: log.LevelWarn, want: "[TIME]: message key=value\n"},
+		{name: "error", level: log.LevelError, want: "[TIME]: message key=value\n"},
+		{name: "debug prefix", level: log.LevelDebug, prefix: "svc", want: "[TIME] svc: message key=value\n"},
+		{name: "info prefix", level: log.LevelInfo, prefix: "svc", want: "[TIME] svc: message key=value\n"},
+		{name: "warn prefix", level: log.LevelWarn, prefix: "svc", want: "[TIME] svc: message key=value\n"},
+		{name: "error prefix", level: log.LevelError, prefix: "svc", want: "[TIME] svc: message key=value\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+
+			logger := log.New(
+				buf,
+				log.WithLevel(log.LevelDebug),
+				log.TimeFunc(fixedTime),
+				log.Prefix(tt.prefix),
+				log.WithoutLevelLabel(),
+			)
+
+			attr := slog.String("key", "value")
+
+			switch tt.level {
+			case log.LevelDebug:
+				logger.Debug("message", attr)
+			case log.LevelInfo:
+				logger.Info("message", attr)
+			case log.LevelWarn:
+				logger.Warn("message", attr)
+			case log.LevelError:
+				logger.Error("message", attr)
+			}
+
+			got := buf.String()
+			got = strings.ReplaceAll(got, fixedTimeString, "[TIME]")
+
+			test.Diff(t, got, tt.want)
+		})
+	}
+}
+
+func TestLevelWidth(t *testing.T) {
+	hue.Enabled(false)
+
+	fixedTime := func() time.Time {
+		fixed, err := time.Parse(time.RFC3339, "2025-04-01T13:34:03Z")
+		test.Ok(t, err)
+
+		return fixed
+	}
+
+	tests := []struct {
+		name   string // Name of the test case
+		prefix string // Optional prefix
+		want   string // Expected output
+		width  int    // The level width
+	}{
+		{
+			name:  "default",
+			width: 0,
+			want: "2025-04-01T13:34:03Z DEBUG: Message\n" +
+				"2025-04-01T13:34:03Z INFO:  Message\n" +
+				"2025-04-01T13:34:03Z WARN:  Message\n" +
+				"2025-04-01T13:34:03Z ERROR: Message\n",
+		},
+		{
+			name:   "default with prefix",
+			width:  0,
+			prefix: "app",
+			want: "2025-04-01T13:34:03Z DEBUG app: Message\n" +
+				"2025-04-01T13:34:03Z INFO app:  Message\n" +
+				"2025-04-01T13:34:03Z WARN app:  Message\n" +
+				"2025-04-01T13:34:03Z ERROR app: Message\n",
+		},
+		{
+			name:  "explicit",
+			width: 7,
+			want: "2025-04-01T13:34:03Z DEBUG  : Message\n" +
+				"2025-04-01T13:34:03Z INFO   : Message\n" +
+				"2025-04-01T13:34:03Z WARN   : Message\n" +
+				"2025-04-01T13:34:03Z ERROR  : Message\n",
+		},
+		{
+			name:   "explicit with prefix",
+			width:  5,
+			prefix: "app",
+			want: "2025-04-01T13:34:03Z DEBUG app: Message\n" +
+				"2025-04-01T13:34:03Z INFO  app: Message\n" +
+				"2025-04-01T13:34:03Z WARN  app: Message\n" +
+				"2025-04-01T13:34:03Z ERROR app: Message\n",
+		},
+		{
+			name:   "narrower than labels",
+			width:  2,
+			prefix: "app",
+			want: "2025-04-01T13:34:03Z DEBUG app: Message\n" +
+				"2025-04-01T13:34:03Z INFO app: Message\n" +
+				"2025-04-01T13:34:03Z WARN app: Message\n" +
+				"2025-04-01T13:34:03Z ERROR app: Message\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+
+			logger := log.New(
+				buf,
+				log.TimeFunc(fixedTime),
+				log.WithLevel(log.LevelDebug),
+				log.WithLevelWidth(tt.width),
+				log.Prefix(tt.prefix),
+			)
+
+			logger.Debug("Message")
+			logger.Info("Message")
+			logger.Warn("Message")
+			logger.Error("Message")
+
+			test.Diff(t, buf.String(), tt.want)
+		})
+	}
+}
+
+func TestPlain(t *testing.T) {
+	hue.Enabled(true) // Colour would normally be on
+	defer hue.Enabled(false)
+
+	fixedTime := func() time.Time {
+		fixed, err := time.Parse(time.RFC3339, "2025-04-01T13:34:03Z")
+		test.Ok(t, err)
+
+		return fixed
+	}
+
+	tests := []struct {
+		name    string       // Name of the test case
+		want    string       // Expected output
+		options []log.Option // Extra options to configure the logger
+	}{
+		{
+			name:    "default",
+			options: nil,
+			want: "INFO:  Building target=all\n" +
+				"WARN:  Slow duration=2s\n",
+		},
+		{
+			name:    "prefix",
+			options: []log.Option{log.Prefix("build")},
+			want: "INFO build:  Building target=all\n" +
+				"WARN build:  Slow duration=2s\n",
+		},
+		{
+			name:    "no level",
+			options: []log.Option{log.WithoutLevelLabel()},
+			want: "Building target=all\n" +
+				"Slow duration=2s\n",
+		},
+		{
+			name:    "timestamp restored",
+			options: []log.Option{log.TimeFormat(time.Kitchen)},
+			want: "1:34PM INFO:  Building target=all\n" +
+				"1:34PM WARN:  Slow duration=2s\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			log.FakeTerminal(t, buf)
+
+			options := append(
+				[]log.Option{
+					log.TimeFunc(fixedTime),
+					log.WithColor(log.ColorAlways),
+					log.WithTerminalClearLine(),
+					log.WithDurationThresholds(time.Second, 5*time.Second),
+					log.WithPlain(),
+				},
+				tt.options...,
+			)
+
+			logger := log.New(buf, options...)
+
+			logger.Debug("Filtered")
+			logger.Info("Building", slog.String("target", "all"))
+			logger.Warn("Slow", slog.Duration("duration", 2*time.Second))
+
+			test.False(t, strings.Contains(buf.String(), "\x1b"), test.Context("output contains ANSI escapes: %q", buf.String()))
+			test.Diff(t, buf.String(), tt.want)
+		})
+	}
+}
+
+func TestCompactLevels(t *testing.T) {
+	hue.Enabled(false)
+
+	fixedTime := func() time.Time {
+		fixed, err := time.Parse(time.RFC3339, "2025-04-01T13:34:03Z")
+		test.Ok(t, err)
+
+		return fixed
+	}
+
+	tests := []struct {
+		name    string       // Name of the test case
+		want    string       // Expected output
+		options []log.Option // Extra options to configure the logger
+	}{
+		{
+			name:    "plain",
+			options: nil,
+			want: "2025-04-01T13:34:03Z D: Message\n" +
+				"2025-04-01T13:34:03Z I: Message\n" +
+				"2025-04-01T13:34:03Z W: Message\n" +
+				"2025-04-01T13:34:03Z E: Message\n",
+		},
+		{
+			name:    "colour",
+			options: []log.Option{log.WithColor(log.ColorAlways), log.TimeFormat(time.Kitchen)},
+			want: "\x1b[2m1:34PM\x1b[0m \x1b[1;34mD\x1b[0m: Message\n" +
+				"\x1b[2m1:34PM\x1b[0m \x1b[1;36mI\x1b[0m: Message\n" +
+				"\x1b[2m1:34PM\x1b[0m \x1b[1;33mW\x1b[0m: Message\n" +
+				"\x1b[2m1:34PM\x1b[0m \x1b[1;31mE\x1b[0m: Message\n",
+		},
+		{
+			name:    "lower case",
+			options: []log.Option{log.WithLevelCase(log.CaseLower)},
+			want: "2025-04-01T13:34:03Z d: Message\n" +
+				"2025-04-01T13:34:03Z i: Message\n" +
+				"2025-04-01T13:34:03Z w: Message\n" +
+				"2025-04-01T13:34:03Z e: Message\n",
+		},
+		{
+			name:    "level width",
+			options: []log.Option{log.WithLevelWidth(3), log.Prefix("app")},
+			want: "2025-04-01T13:34:03Z D   app: Message\n" +
+				"2025-04-01T13:34:03Z I   app: Message\n" +
+				"2025-04-01T13:34:03Z W   app: Message\n" +
+				"2025-04-01T13:34:03Z E   app: Message\n",
+		},
+		{
+			name:    "json",
+			options: []log.Option{log.WithJSON()},
+			want: `{"time":"2025-04-01T13:34:03Z","level":"DEBUG","msg":"Message"}` + "\n" +
+				`{"time":"2025-04-01T13:34:03Z","level":"INFO","msg":"Message"}` + "\n" +
+				`{"time":"2025-04-01T13:34:03Z","level":"WARN","msg":"Message"}` + "\n" +
+				`{"time":"2025-04-01T13:34:03Z","level":"ERROR","msg":"Message"}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+
+			options := append(
+				[]log.Option{log.TimeFunc(fixedTime), log.WithLevel(log.LevelDebug), log.WithCompactLevels()},
+				tt.options...,
+			)
+
+			logger := log.New(buf, options...)
+
+			logger.Debug("Message")
+			logger.Info("Message")
+			logger.Warn("Message")
+			logger.Error("Message")
+
+			test.Diff(t, buf.String(), tt.want)
+		})
+	}
+}
+
+func TestLevelCase(t *testing.T) {
+	hue.Enabled(false)
+
+	fixedTime := func() time.Time {
+		fixed, err := time.Parse(time.RFC3339, "2025-04-01T13:34:03Z")
+		test.Ok(t, err)
+
+		return fixed
+	}
+
+	tests := []struct {
+		name       string        // Name of the test case
+		want       string        // Expected output
+		letterCase log.LevelCase // The level case under test
+	}{
+		{
+			name:       "upper",
+			letterCase: log.CaseUpper,
+			want: "2025-04-01T13:34:03Z DEBUG: Message\n" +
+				"2025-04-01T13:34:03Z INFO:  Message\n" +
+				"2025-04-01T13:34:03Z WARN:  Message\n" +
+				"2025-04-01T13:34:03Z ERROR: Message\n",
+		},
+		{
+			name:       "lower",
+			letterCase: log.CaseLower,
+			want: "2025-04-01T13:34:03Z debug: Message\n" +
+				"2025-04-01T13:34:03Z info:  Message\n" +
+				"2025-04-01T13:34:03Z warn:  Message\n" +
+				"2025-04-01T13:34:03Z error: Message\n",
+		},
+		{
+			name:       "title",
+			letterCase: log.CaseTitle,
+			want: "2025-04-01T13:34:03Z Debug: Message\n" +
+				"2025-04-01T13:34:03Z Info:  Message\n" +
+				"2025-04-01T13:34:03Z Warn:  Message\n" +
+				"2025-04-01T13:34:03Z Error: Message\n",
+		},
+		{
+			name:       "unknown",
+			letterCase: log.LevelCase(42),
+			want: "2025-04-01T13:34:03Z DEBUG: Message\n" +
+				"2025-04-01T13:34:03Z INFO:  Message\n" +
+				"2025-04-01T13:34:03Z WARN:  Message\n" +
+				"2025-04-01T13:34:03Z ERROR: Message\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+
+			logger := log.New(buf, log.TimeFunc(fixedTime), log.WithLevel(log.LevelDebug), log.WithLevelCase(tt.letterCase))
+
+			logger.Debug("Message")
+			logger.Info("Message")
+			logger.Warn("Message")
+			logger.Error("Message")
+
+			test.Diff(t, buf.String(), tt.want)
+		})
+	}
+}
+
+func TestLevelPrefix(t *testing.T) {
+	fixedTime := func() time.Time {
+		fixed, err := time.Parse(time.RFC3339, "2025-04-01T13:34:03Z")
+		test.Ok(t, err)
+
+		return fixed
+	}
+
+	prefixes := map[log.Level]string{
+		log.LevelError: "FATAL ERROR",
+		log.LevelWarn:  "HEADS UP",
+	}
+
+	t.Run("plain", func(t *testing.T) {
+		hue.Enabled(false)
+
+		buf := &bytes.Buffer{}
+		logger := log.New(buf, log.TimeFunc(fixedTime), log.WithLevelPrefix(prefixes))
+
+		logger.Info("Starting")
+		logger.Warn("Low disk")
+		logger.Prefixed("disk").Error("Disk full")
+
+		want := "2025-04-01T13:34:03Z INFO:  Starting\n" +
+			"2025-04-01T13:34:03Z WARN HEADS UP:  Low disk\n" +
+			"2025-04-01T13:34:03Z ERROR FATAL ERROR disk: Disk full\n"
+
+		test.Diff(t, buf.String(), want)
+	})
+
+	t.Run("colour", func(t *testing.T) {
+		hue.Enabled(false)
+
+		buf := &bytes.Buffer{}
+		logger := log.New(buf, log.TimeFunc(fixedTime), log.WithLevelPrefix(prefixes), log.WithColor(log.ColorAlways))
+
+		logger.Error("Disk full")
+
+		test.True(
+			t,
+			strings.Contains(buf.String(), "\x1b[1;31mERROR\x1b[0m \x1b[1;31mFATAL ERROR\x1b[0m: "),
+			test.Context("tag not styled like the level: %q", buf.String()),
+		)
+	})
+}
+
+func TestLevelColoredPrefix(t *testing.T) {
+	tests := []struct {
+		name  string        // Name of the test case
+		want  string        // Expected log output
+		mode  log.ColorMode // Colour mode of the logger
+		level log.Level     // Level to log at
+	}{
+		{
+			name:  "info",
+			mode:  log.ColorAlways,
+			level: log.LevelInfo,
+			want:  "\x1b[1;36mINFO\x1b[0m \x1b[1;36moven\x1b[0m:  Preheating\n",
+		},
+		{
+			name:  "error",
+			mode:  log.ColorAlways,
+			level: log.LevelError,
+			want:  "\x1b[1;31mERROR\x1b[0m \x1b[1;31moven\x1b[0m: Preheating\n",
+		},
+		{
+			name:  "no colour",
+			mode:  log.ColorNever,
+			level: log.LevelError,
+			want:  "ERROR oven: Preheating\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			logger := log.New(buf, log.WithPlain(), log.WithColor(tt.mode), log.WithLevelColoredPrefix())
+
+			logger.Prefixed("oven").Log(tt.level, "Preheating")
+
+			test.Diff(t, buf.String(), tt.want)
+		})
+	}
+}
+
+func TestPunctuationStyle(t *testing.T) {
+	fixedTime := func() time.Time {
+		return time.Date(2025, time.April, 1, 13, 34, 3, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name string        // Name of the test case
+		want string        // Expected log line
+		mode log.ColorMode // Colour mode of the logger
+	}{
+		{
+			name: "colour",
+			mode: log.ColorAlways,
+			want: "\x1b[2m2025-04-01T13:34:03Z\x1b[0m \x1b[1;36mINFO\x1b[0m \x1b[1;2moven\x1b[0m\x1b[2m:\x1b[0m  Preheating\n",
+		},
+		{
+			name: "no colour",
+			mode: log.ColorNever,
+			want: "2025-04-01T13:34:03Z INFO oven:  Preheating\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			logger := log.New(buf, log.TimeFunc(fixedTime), log.WithColor(tt.mode), log.WithPunctuationStyle(hue.Dim))
+
+			logger.Prefixed("oven").Info("Preheating")
+
+			test.Diff(t, buf.String(), tt.want)
+		})
+	}
+}
+
+func TestLineHook(t *testing.T) {
+	hue.Enabled(false) // Force no color
+
+	// Constantly return the same time
+	fixedTime := func() time.Time {
+		fixed, err := time.Parse(time.RFC3339, "2025-04-01T13:34:03Z")
+		test.Ok(t, err)
+
+		return fixed
+	}
+
+	type call struct {
+		line  string
+		level log.Level
+	}
+
+	var calls []call
+
+	hook := func(level log.Level, line []byte) {
+		// Must copy, the line is only valid during the call
+		calls = append(calls, call{level: level, line: string(line)})
+	}
+
+	buf := &bytes.Buffer{}
+	logger := log.New(buf, log.TimeFunc(fixedTime), log.WithLineHook(hook))
+
+	logger.Debug("Filtered out")
+	logger.Info("Hello", slog.Int("number", 42))
+	logger.Error("Uh oh")
+
+	want := []call{
+		{level: log.LevelInfo, line: "2025-04-01T13:34:03Z INFO:  Hello number=42\n"},
+		{level: log.LevelError, line: "2025-04-01T13:34:03Z ERROR: Uh oh\n"},
+	}
+
+	test.EqualFunc(t, calls, want, slices.Equal)
+	test.Equal(t, buf.String(), want[0].line+want[1].line, test.Context("hook should not affect output"))
+}
+
+func TestEnabled(t *testing.T) {
+	tests := []struct {
+		name  string    // Name of the test case
+		w     io.Writer // Where the logger writes to
+		level log.Level // Level the logger is configured at
+		check log.Level // Level to pass to Enabled
+		want  bool      // Expected result
+	}{
+		{name: "debug at debug", w: &bytes.Buffer{}, level: log.LevelDebug, check: log.LevelDebug, want: true},
+		{name: "debug at info", w: &bytes.Buffer{}, level: log.LevelInfo, check: log.LevelDebug, want: false},
+		{name: "error at warn", w: &bytes.Buffer{}, level: log.LevelWarn, check: log.LevelError, want: true},
+		{name: "discard", w: io.Discard, level: log.LevelDebug, check: log.LevelError, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := log.New(tt.w, log.WithLevel(tt.level))
+			test.Equal(t, logger.Enabled(tt.check), tt.want)
+		})
+	}
+}
+
+func TestLevelEnabled(t *testing.T) {
+	tests := []struct {
+		name  string    // Name of the test case
+		want  [4]bool   // Expected results of DebugEnabled, InfoEnabled, WarnEnabled and ErrorEnabled
+		level log.Level // Level the logger is configured at
+	}{
+		{name: "debug", level: log.LevelDebug, want: [4]bool{true, true, true, true}},
+		{name: "info", level: log.LevelInfo, want: [4]bool{false, true, true, true}},
+		{name: "warn", level: log.LevelWarn, want: [4]bool{false, false, true, true}},
+		{name: "error", level: log.LevelError, want: [4]bool{false, false, false, true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := log.New(&bytes.Buffer{}, log.WithLevel(tt.level))
+
+			got := [4]bool{logger.DebugEnabled(), logger.InfoEnabled(), logger.WarnEnabled(), logger.ErrorEnabled()}
+			test.Equal(t, got, tt.want)
+		})
+	}
+}
+
+func TestPrefixLevel(t *testing.T) {
+	hue.Enabled(false)
+
+	fixedTime := func() time.Time {
+		fixed, err := time.Parse(time.RFC3339, "2025-04-01T13:34:03Z")
+		test.Ok(t, err)
+
+		return fixed
+	}
+
+	buf := &bytes.Buffer{}
+
+	logger := log.New(
+		buf,
+		log.TimeFunc(fixedTime),
+		log.WithLevel(log.LevelInfo),
+		log.WithPrefixLevel(map[string]log.Level{
+			"http": log.LevelDebug,
+			"db":   log.LevelError,
+		}),
+	)
+
+	logger.Debug("Unprefixed debug")
+	logger.Info("Unprefixed info")
+	logger.Prefixed("http").Debug("HTTP debug")
+	logger.Prefixed("db").Warn("DB warning")
+	logger.Prefixed("db").Error("DB error")
+	logger.Prefixed("other").Debug("Other debug")
+
+	test.True(t, logger.Prefixed("http").DebugEnabled())
+	test.False(t, logger.DebugEnabled())
+
+	want := "2025-04-01T13:34:03Z INFO:  Unprefixed info\n" +
+		"2025-04-01T13:34:03Z DEBUG http: HTTP debug\n" +
+		"2025-04-01T13:34:03Z ERROR db: DB error\n"
+
+	test.Diff(t, buf.String(), want)
+}
+
+func TestClampLevel(t *testing.T) {
+	hue.Enabled(false) // Force no color
+
+	tests := []struct {
+		name     string       // Name of the test case
+		options  []log.Option // Options to configure the logger
+		apply    *log.Level   // If set, the level to Apply after construction
+		enabled  []log.Level  // Levels that should be enabled
+		disabled []log.Level  // Levels that should be disabled
+	}{
+		{
+			name:     "within bounds",
+			options:  []log.Option{log.WithClampLevel(log.LevelInfo, log.LevelWarn)},
+			enabled:  []log.Level{log.LevelInfo, log.LevelWarn, log.LevelError},
+			disabled: []log.Level{log.LevelDebug},
+		},
+		{
+			name:     "too verbose",
+			options:  []log.Option{log.WithLevel(log.LevelDebug), log.WithClampLevel(log.LevelWarn, log.LevelError)},
+			enabled:  []log.Level{log.LevelWarn, log.LevelError},
+			disabled: []log.Level{log.LevelDebug, log.LevelInfo},
+		},
+		{
+			name:     "too quiet",
+			options:  []log.Option{log.WithLevel(log.LevelError + 4), log.WithClampLevel(log.LevelDebug, log.LevelWarn)},
+			enabled:  []log.Level{log.LevelWarn, log.LevelError},
+			disabled: []log.Level{log.LevelDebug, log.LevelInfo},
+		},
+		{
+			name:     "apply",
+			options:  []log.Option{log.WithClampLevel(log.LevelInfo, log.LevelWarn)},
+			apply:    new(log.LevelDebug),
+			enabled:  []log.Level{log.LevelInfo, log.LevelWarn},
+			disabled: []log.Level{log.LevelDebug},
+		},
+		{
+			name:     "swapped",
+			options:  []log.Option{log.WithLevel(log.LevelDebug), log.WithClampLevel(log.LevelError, log.LevelWarn)},
+			enabled:  []log.Level{log.LevelWarn, log.LevelError},
+			disabled: []log.Level{log.LevelDebug, log.LevelInfo},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := log.New(&bytes.Buffer{}, tt.options...)
+
+			if tt.apply != nil {
+				cfg := logger.Config()
+				cfg.Level = *tt.apply
+				logger.Apply(cfg)
+			}
+
+			for _, level := range tt.enabled {
+				test.True(t, logger.Enabled(level), test.Context("%s should be enabled", level))
+			}
+
+			for _, level := range tt.disabled {
+				test.False(t, logger.Enabled(level), test.Context("%s should be disabled", level))
+			}
+		})
+	}
+
+	t.Run("prefix level", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		logger := log.New(
+			buf,
+			log.WithPlain(),
+			log.WithClampLevel(log.LevelInfo, log.LevelWarn),
+			log.WithPrefixLevel(map[string]log.Level{"http": log.LevelDebug}),
+		)
+
+		sub := logger.Prefixed("http")
+		sub.Debug("Hidden")
+		sub.Info("Shown")
+
+		test.Diff(t, buf.String(), "INFO http:  Shown\n")
+	})
+}
+
+func TestMutePrefix(t *testing.T) {
+	hue.Enabled(false) // Force no color
+
+	fixedTime := func() time.Time {
+		return time.Date(2025, time.April, 1, 13, 34, 3, 0, time.UTC)
+	}
+
+	buf := &bytes.Buffer{}
+	logger := log.New(buf, log.TimeFunc(fixedTime))
+	cache := logger.Prefixed("cache")
+	server := logger.Prefixed("server")
+
+	cache.MutePrefix("cache")
+
+	test.False(t, cache.InfoEnabled(), test.Context("muted prefix should not be enabled"))
+
+	logger.Info("Starting")
+	cache.Error("Miss")
+	server.Info("Listening")
+	logger.Named("cache").Named("redis").Info("Connected")
+
+	logger.UnmutePrefix("cache")
+	logger.UnmutePrefix("not muted")
+
+	cache.Info("Hit")
+
+	want := "2025-04-01T13:34:03Z INFO:  Starting\n" +
+		"2025-04-01T13:34:03Z INFO server:  Listening\n" +
+		"2025-04-01T13:34:03Z INFO cache.redis:  Connected\n" +
+		"2025-04-01T13:34:03Z INFO cache:  Hit\n"
+
+	test.Diff(t, buf.String(), want)
+}
+
+func TestGlobalMinLevel(t *testing.T) {
+	hue.Enabled(false) // Force no color
+
+	// Reset after so we don't affect other tests
+	t.Cleanup(func() { log.SetGlobalMinLevel(log.LevelDebug) })
+
+	buf := &bytes.Buffer{}
+	logger := log.New(buf, log.WithLevel(log.LevelDebug))
+	sub := logger.Prefixed("sub")
+
+	log.SetGlobalMinLevel(log.LevelWarn)
+
+	test.False(t, logger.Enabled(log.LevelDebug), test.Context("debug should be below the global floor"))
+	test.False(t, sub.Enabled(log.LevelInfo), test.Context("info should be below the global floor"))
+	test.True(t, sub.Enabled(log.LevelWarn), test.Context("warn should be at the global floor"))
+
+	logger.Debug("Hidden")
+	sub.Info("Hidden")
+	test.Equal(t, buf.String(), "", test.Context("expected no output below the global floor"))
+
+	sub.Error("Shown")
+	test.True(t, strings.Contains(buf.String(), "Shown"), test.Context("expected error line to be shown"))
+}
+
+func TestWrapWidth(t *testing.T) {
+	// Constantly return the same time
+	fixedTime := func() time.Time {
+		fixed, err := time.Parse(time.RFC3339, "2025-04-01T13:34:03Z")
+		test.Ok(t, err)
+
+		return fixed
+	}
+
+	want := "2025-04-01T13:34:03Z INFO:  Message first=one\n" +
+		"                            second=two third=three\n" +
+		"                            fourth=4\n"
+
+	// Any ANSI escape sequence
+	ansi := regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+	for _, color := range []bool{false, true} {
+		t.Run(fmt.Sprintf("color %v", color), func(t *testing.T) {
+			hue.Enabled(color)
+
+			buf := &bytes.Buffer{}
+			logger := log.New(buf, log.TimeFunc(fixedTime), log.WithWrapWidth(50))
+
+			logger.Info(
+				"Message",
+				slog.String("first", "one"),
+				slog.String("second", "two"),
+				slog.String("third", "three"),
+				slog.Int("fourth", 4),
+			)
+
+			test.Equal(t, ansi.MatchString(buf.String()), color, test.Context("unexpected escape codes"))
+			test.Diff(t, ansi.ReplaceAllString(buf.String(), ""), want)
+		})
+	}
+
+	t.Run("too long for a line", func(t *testing.T) {
+		hue.Enabled(false)
+
+		buf := &bytes.Buffer{}
+		logger := log.New(buf, log.TimeFunc(fixedTime), log.WithWrapWidth(10))
+
+		logger.Info("Message", slog.String("first", "one"), slog.String("second", "two"))
+
+		want := "2025-04-01T13:34:03Z INFO:  Message\n" +
+			"                            first=one\n" +
+			"                            second=two\n"
+
+		test.Diff(t, buf.String(), want)
+	})
+}
+
+func TestTrack(t *testing.T) {
+	hue.Enabled(false) // Force no color
+
+	// A clock that moves forward by a second every time it's read
+	clock := func() func() time.Time {
+		now, err := time.Parse(time.RFC3339, "2025-04-01T13:34:03Z")
+		test.Ok(t, err)
+
+		return func() time.Time {
+			now = now.Add(time.Second)
+			return now
+		}
+	}
+
+	t.Run("success", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		logger := log.New(buf, log.WithLevel(log.LevelDebug), log.TimeFunc(clock()), log.TimeFormat(time.TimeOnly))
+
+		called := false
+		err := logger.Track("Baking", func() error {
+			called = true
+			return nil
+		})
+
+		test.Ok(t, err)
+		test.True(t, called, test.Context("fn was not called"))
+
+		// The debug line reads the clock once to render, then Track reads it at the start
+		// and end of fn, and the final line reads it once more
+		want := "13:34:04 DEBUG: Baking\n13:34:07 INFO:  Baking duration=1s\n"
+		test.Diff(t, buf.String(), want)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		logger := log.New(buf, log.WithLevel(log.LevelDebug), log.TimeFunc(clock()), log.TimeFormat(time.TimeOnly))
+
+		err := logger.Track("Baking", func() error {
+			return errors.New("oven on fire")
+		})
+
+		test.Err(t, err)
+
+		want := "13:34:04 DEBUG: Baking\n13:34:07 ERROR: Baking duration=1s err=\"oven on fire\"\n"
+		test.Diff(t, buf.String(), want)
+	})
+
+	t.Run("warn above", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		logger := log.New(buf, log.WithLevel(log.LevelDebug), log.TimeFunc(clock()), log.TimeFormat(time.TimeOnly))
+
+		err := logger.Track("Fast", func() error { return nil }, log.WarnAbove(2*time.Second))
+		test.Ok(t, err)
+
+		err = logger.Track("Slow", func() error { return nil }, log.WarnAbove(time.Second))
+		test.Ok(t, err)
+
+		want := "13:34:04 DEBUG: Fast\n13:34:07 DEBUG: Fast duration=1s\n" +
+			"13:34:08 DEBUG: Slow\n13:34:11 WARN:  Slow duration=1s\n"
+		test.Diff(t, buf.String(), want)
+	})
+}
+
+func TestTimer(t *testing.T) {
+	hue.Enabled(false) // Force no color
+
+	// A clock that only moves when told to
+	now := time.Date(2025, time.April, 1, 13, 34, 3, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	tests := []struct {
+		name    string            // Name of the test case
+		want    string            // Expected log output
+		options []log.TimerOption // Options to pass to Timer
+		elapsed time.Duration     // How long the operation takes
+	}{
+		{
+			name:    "default",
+			elapsed: 200 * time.Millisecond,
+			want:    "INFO:  Querying duration=200ms\n",
+		},
+		{
+			name:    "fast",
+			options: []log.TimerOption{log.WarnAbove(500 * time.Millisecond)},
+			elapsed: 200 * time.Millisecond,
+			want:    "DEBUG: Querying duration=200ms\n",
+		},
+		{
+			name:    "slow",
+			options: []log.TimerOption{log.WarnAbove(500 * time.Millisecond)},
+			elapsed: 2 * time.Second,
+			want:    "WARN:  Querying duration=2s\n",
+		},
+		{
+			name:    "threshold ignored",
+			options: []log.TimerOption{log.WarnAbove(0)},
+			elapsed: 2 * time.Second,
+			want:    "INFO:  Querying duration=2s\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			logger := log.New(buf, log.WithPlain(), log.WithLevel(log.LevelDebug), log.TimeFunc(clock))
+
+			stop := logger.Timer("Querying", tt.options...)
+
+			now = now.Add(tt.elapsed)
+
+			stop()
+			stop() // Only the first call logs
+
+			test.Diff(t, buf.String(), tt.want)
+		})
+	}
+}
+
+func TestSince(t *testing.T) {
+	hue.Enabled(false) // Force no color
+
+	start := time.Date(2025, time.April, 1, 13, 34, 3, 0, time.UTC)
+	fixedTime := func() time.Time {
+		return start.Add(90 * time.Second)
+	}
+
+	buf := &bytes.Buffer{}
+	logger := log.New(buf, log.TimeFunc(fixedTime), log.TimeFormat(time.TimeOnly))
+
+	logger.Info("Build finished", logger.Since("elapsed", start))
+
+	test.Diff(t, buf.String(), "13:35:33 INFO:  Build finished elapsed=1m30s\n")
+}
+
+func TestLogAt(t *testing.T) {
+	hue.Enabled(false) // Force no color
+
+	now := func() time.Time {
+		return time.Date(2025, time.April, 1, 13, 34, 3, 0, time.UTC)
+	}
+
+	at := time.Date(2024, time.December, 25, 9, 30, 0, 0, time.UTC)
+
+	t.Run("text", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		logger := log.New(buf, log.TimeFunc(now), log.TimeFormat(time.DateTime))
+
+		logger.LogAt(at, log.LevelWarn, "Replayed", slog.String("event", "deploy"))
+		logger.LogAt(at, log.LevelDebug, "Not shown")
+
+		test.Diff(t, buf.String(), "2024-12-25 09:30:00 WARN:  Replayed event=deploy\n")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		logger := log.New(buf, log.TimeFunc(now), log.WithJSON())
+
+		logger.LogAt(at, log.LevelInfo, "Replayed")
+
+		test.Diff(t, buf.String(), `{"time":"2024-12-25T09:30:00Z","level":"INFO","msg":"Replayed"}`+"\n")
+	})
+}
+
+func TestDurationThresholds(t *testing.T) {
+	hue.Enabled(true) // Force colour
+	t.Cleanup(func() { hue.Enabled(false) })
+
+	tests := []struct {
+		name     string        // Name of the test case
+		want     string        // The expected styled value
+		duration time.Duration // The duration to log
+	}{
+		{name: "fast", duration: 10 * time.Millisecond, want: hue.Green.Text("10ms")},
+		{name: "medium", duration: 500 * time.Millisecond, want: hue.Yellow.Text("500ms")},
+		{name: "slow", duration: 2 * time.Second, want: hue.Red.Text("2s")},
+		{name: "on the boundary", duration: time.Second, want: hue.Red.Text("1s")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			logger := log.New(buf, log.WithDurationThresholds(100*time.Millisecond, time.Second))
+
+			logger.Info("Done", slog.Duration("took", tt.duration))
+
+			got := buf.String()
+			test.True(t, strings.Contains(got, "="+tt.want+"\n"), test.Context("%q does not contain %q", got, tt.want))
+		})
+	}
+
+	t.Run("no colour", func(t *testing.T) {
+		hue.Enabled(false)
+
+		buf := &bytes.Buffer{}
+		logger := log.New(buf, log.WithDurationThresholds(100*time.Millisecond, time.Second))
+
+		logger.Info("Done", slog.Duration("took", 2*time.Second))
+
+		test.True(t, strings.HasSuffix(buf.String(), " took=2s\n"), test.Context("got %q", buf.String()))
+	})
+}
+
+func TestTerminalClearLine(t *testing.T) {
+	hue.Enabled(false) // Force no color
+
+	t.Run("terminal", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		log.FakeTerminal(t, buf)
+
+		logger := log.New(buf, log.WithTerminalClearLine())
+		logger.Info("One")
+		logger.Prefixed("sub").Info("Two")
+
+		lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+		test.Equal(t, len(lines), 2)
+
+		for _, line := range lines {
+			test.True(t, strings.HasPrefix(line, "\r\x1b[K"), test.Context("line %q missing clear sequence", line))
+		}
+	})
+
+	t.Run("not a terminal", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+
+		logger := log.New(buf, log.WithTerminalClearLine())
+		logger.Info("One")
+
+		test.False(t, strings.Contains(buf.String(), "\r\x1b[K"), test.Context("unexpected clear sequence"))
+	})
+}
+
+func TestRegisterLevel(t *testing.T) {
+	hue.Enabled(false) // Force no color
+
+	// Constantly return the same time
+	fixedTime := func() time.Time {
+		fixed, err := time.Parse(time.RFC3339, "2025-04-01T13:34:03Z")
+		test.Ok(t, err)
+
+		return fixed
+	}
+
+	const levelNotice log.Level = 2
+
+	log.RegisterLevel(levelNotice, "NOTE", hue.Green)
+
+	test.Equal(t, levelNotice.String(), "NOTE")
+
+	tests := []struct {
+		name  string    // Name of the test case
+		want  string    // Expected output
+		level log.Level // Level to configure the logger with
+	}{
+		{name: "shown at info", level: log.LevelInfo, want: "2025-04-01T13:34:03Z NOTE:  Heads up\n"},
+		{name: "shown at notice", level: levelNotice, want: "2025-04-01T13:34:03Z NOTE:  Heads up\n"},
+		{name: "filtered at warn", level: log.LevelWarn, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			logger := log.New(buf, log.WithLevel(tt.level), log.TimeFunc(fixedTime))
+
+			logger.Log(levelNotice, "Heads up")
+
+			test.Diff(t, buf.String(), tt.want)
+		})
+	}
+}
+
+func TestUnitHints(t *testing.T) {
+	hue.Enabled(false)
+
+	fixedTime := func() time.Time {
+		fixed, err := time.Parse(time.RFC3339, "2025-04-01T13:34:03Z")
+		test.Ok(t, err)
+
+		return fixed
+	}
+
+	units := map[string]string{"size": "B", "latency": "ms", "ratio": "x", "name": "?"}
+
+	tests := []struct {
+		name string    // Name of the test case
+		want string    // Expected log line
+		attr slog.Attr // The attr to log
+	}{
+		{
+			name: "int",
+			attr: slog.Int("size", 1024),
+			want: "2025-04-01T13:34:03Z INFO:  Fetched size=1024B\n",
+		},
+		{
+			name: "uint",
+			attr: slog.Uint64("latency", 57),
+			want: "2025-04-01T13:34:03Z INFO:  Fetched latency=57ms\n",
+		},
+		{
+			name: "float",
+			attr: slog.Float64("ratio", 1.5),
+			want: "2025-04-01T13:34:03Z INFO:  Fetched ratio=1.5x\n",
+		},
+		{
+			name: "no matching key",
+			attr: slog.Int("count", 3),
+			want: "2025-04-01T13:34:03Z INFO:  Fetched count=3\n",
+		},
+		{
+			name: "not a number",
+			attr: slog.String("name", "tom"),
+			want: "2025-04-01T13:34:03Z INFO:  Fetched name=tom\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			logger := log.New(buf, log.TimeFunc(fixedTime), log.WithUnitHints(units))
+
+			logger.Info("Fetched", tt.attr)
+
+			test.Diff(t, buf.String(), tt.want)
+		})
+	}
+}
+
+func TestMessageAsAttr(t *testing.T) {
+	hue.Enabled(false)
+
+	fixedTime := func() time.Time {
+		fixed, err := time.Parse(time.RFC3339, "2025-04-01T13:34:03Z")
+		test.Ok(t, err)
+
+		return fixed
+	}
+
+	buf := &bytes.Buffer{}
+	logger := log.New(buf, log.TimeFunc(fixedTime), log.WithMessageAsAttr()).With(slog.String("user", "tom"))
+
+	logger.Info("Pizza is ready", slog.String("flavour", "pepperoni"))
+	logger.Prefixed("oven").Warn("hot")
+	logger.Error("")
+
+	want := "2025-04-01T13:34:03Z INFO:  msg=\"Pizza is ready\" user=tom flavour=pepperoni\n" +
+		"2025-04-01T13:34:03Z WARN oven:  msg=hot user=tom\n" +
+		"2025-04-01T13:34:03Z ERROR: msg=\"\" user=tom\n"
+
+	test.Diff(t, buf.String(), want)
+}
+
+func TestFlagStyleBools(t *testing.T) {
+	hue.Enabled(false)
+
+	fixedTime := func() time.Time {
+		fixed, err := time.Parse(time.RFC3339, "2025-04-01T13:34:03Z")
+		test.Ok(t, err)
+
+		return fixed
+	}
+
+	buf := &bytes.Buffer{}
+	logger := log.New(buf, log.TimeFunc(fixedTime), log.WithFlagStyleBools())
+
+	logger.Info("Running", slog.Bool("verbose", true), slog.Bool("dry-run", false), slog.Int("jobs", 4))
+	logger.Info("Only false", slog.Bool("force", false))
+
+	want := "2025-04-01T13:34:03Z INFO:  Running verbose jobs=4\n" +
+		"2025-04-01T13:34:03Z INFO:  Only false\n"
+
+	test.Diff(t, buf.String(), want)
+}
+
+func TestLineEnding(t *testing.T) {
+	hue.Enabled(false) // Force no color
+
+	fixedTime := func() time.Time {
+		return time.Date(2025, time.April, 1, 13, 34, 3, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name    string       // Name of the test case
+		want    string       // Expected log output
+		options []log.Option // Options to configure the logger
+	}{
+		{
+			name: "default",
+			want: "INFO:  One\nWARN:  Two key=value\n",
+		},
+		{
+			name:    "lf",
+			options: []log.Option{log.WithLineEnding("\r\n"), log.WithLineEnding("\n")},
+			want:    "INFO:  One\nWARN:  Two key=value\n",
+		},
+		{
+			name:    "crlf",
+			options: []log.Option{log.WithLineEnding("\r\n")},
+			want:    "INFO:  One\r\nWARN:  Two key=value\r\n",
+		},
+		{
+			name:    "invalid",
+			options: []log.Option{log.WithLineEnding("\r")},
+			want:    "INFO:  One\nWARN:  Two key=value\n",
+		},
+		{
+			name:    "crlf wrapped",
+			options: []log.Option{log.WithLineEnding("\r\n"), log.WithWrapWidth(14)},
+			want:    "INFO:  One\r\nWARN:  Two\r\n       key=value\r\n",
+		},
+		{
+			name:    "crlf json",
+			options: []log.Option{log.WithLineEnding("\r\n"), log.WithJSON()},
+			want: `{"time":"2025-04-01T13:34:03Z","level":"INFO","msg":"One"}` + "\r\n" +
+				`{"time":"2025-04-01T13:34:03Z","level":"WARN","msg":"Two","key":"value"}` + "\r\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			logger := log.New(buf, append([]log.Option{log.WithPlain(), log.TimeFunc(fixedTime)}, tt.options...)...)
+
+			logger.Info("One")
+			logger.Warn("Two", slog.String("key", "value"))
+
+			test.Diff(t, buf.String(), tt.want)
+		})
+	}
+
+	t.Run("capture", func(t *testing.T) {
+		logger := log.New(io.Discard, log.WithPlain(), log.WithCapture(), log.WithLineEnding("\r\n"))
+
+		logger.Info("One")
+
+		test.EqualFunc(t, logger.Captured(), []string{"INFO:  One"}, slices.Equal)
+	})
+}
+
+func TestQuoteChar(t *testing.T) {
+	hue.Enabled(false) // Force no color
+
+	tests := []struct {
+		name    string       // Name of the test case
+		value   string       // The attr value to log
+		want    string       // Expected log line
+		options []log.Option // Options to configure the logger
+	}{
+		{
+			name:  "default",
+			value: `say "hi" now`,
+			want:  `INFO:  Quoting "a key"="say \"hi\" now"` + "\n",
+		},
+		{
+			name:    "single",
+			options: []log.Option{log.WithQuoteChar('\'')},
+			value:   `say "hi" now`,
+			want:    `INFO:  Quoting 'a key'='say "hi" now'` + "\n",
+		},
+		{
+			name:    "single escaped",
+			options: []log.Option{log.WithQuoteChar('\'')},
+			value:   "it's a\ttab\\",
+			want:    `INFO:  Quoting 'a key'='it\'s a\ttab\\'` + "\n",
+		},
+		{
+			name:    "single invalid utf8",
+			options: []log.Option{log.WithQuoteChar('\'')},
+			value:   "bad \xff byte \ufffd",
+			want:    `INFO:  Quoting 'a key'='bad \xff byte �'` + "\n",
+		},
+		{
+			name:    "unquoted",
+			options: []log.Option{log.WithQuoteChar('\'')},
+			value:   "plain",
+			want:    `INFO:  Quoting 'a key'=plain` + "\n",
+		},
+		{
+			name:    "letter ignored",
+			options: []log.Option{log.WithQuoteChar('q')},
+			value:   "a b",
+			want:    `INFO:  Quoting "a key"="a b"` + "\n",
+		},
+		{
+			name:    "space ignored",
+			options: []log.Option{log.WithQuoteChar(' ')},
+			value:   "a b",
+			want:    `INFO:  Quoting "a key"="a b"` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			logger := log.New(buf, append([]log.Option{log.WithPlain()}, tt.options...)...)
+
+			logger.Info("Quoting", slog.String("a key", tt.value))
+
+			test.Diff(t, buf.String(), tt.want)
+		})
+	}
+}
+
+func TestSmartPathQuoting(t *testing.T) {
+	hue.Enabled(false) // Force no color
+
+	tests := []struct {
+		name    string       // Name of the test case
+		value   string       // The attr value to log
+		want    string       // Expected log line
+		options []log.Option // Options to configure the logger
+	}{
+		{
+			name:    "url",
+			options: []log.Option{log.WithSmartPathQuoting()},
+			value:   "https://example.com/search?q=deep dish",
+			want:    "INFO:  Fetching target=https://example.com/search?q=deep%20dish\n",
+		},
+		{
+			name:    "path",
+			options: []log.Option{log.WithSmartPathQuoting()},
+			value:   "./my files/pizza\tmenu.txt",
+			want:    "INFO:  Fetching target=./my%20files/pizza%09menu.txt\n",
+		},
+		{
+			name:    "unicode",
+			options: []log.Option{log.WithSmartPathQuoting()},
+			value:   "~/café\u00a0menu",
+			want:    "INFO:  Fetching target=~/café%C2%A0menu\n",
+		},
+		{
+			name:    "literal percent",
+			options: []log.Option{log.WithSmartPathQuoting()},
+			value:   "/menus/100% pizza.txt",
+			want:    "INFO:  Fetching target=/menus/100%25%20pizza.txt\n",
+		},
+		{
+			name:    "not a path",
+			options: []log.Option{log.WithSmartPathQuoting()},
+			value:   "deep dish",
+			want:    "INFO:  Fetching target=\"deep dish\"\n",
+		},
+		{
+			name:    "not a scheme",
+			options: []log.Option{log.WithSmartPathQuoting()},
+			value:   "1http://example.com/a b",
+			want:    "INFO:  Fetching target=\"1http://example.com/a b\"\n",
+		},
+		{
+			name:    "off by default",
+			options: nil,
+			value:   "https://example.com/search?q=deep dish",
+			want:    "INFO:  Fetching target=\"https://example.com/search?q=deep dish\"\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			logger := log.New(buf, append([]log.Option{log.WithPlain()}, tt.options...)...)
+
+			logger.Info("Fetching", slog.String("target", tt.value))
+
+			test.Diff(t, buf.String(), tt.want)
+		})
+	}
+}
+
+func TestProcessInfo(t *testing.T) {
+	hue.Enabled(false)
+
+	hostname, err := os.Hostname()
+	test.Ok(t, err)
+
+	buf := &bytes.Buffer{}
+	logger := log.New(buf, log.WithProcessInfo())
+
+	logger.Info("One")
+	logger.With(slog.Int("n", 2)).Warn("Two")
+	logger.Prefixed("sub").Error("Three")
+
+	want := fmt.Sprintf(" pid=%d hostname=%s", os.Getpid(), hostname)
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	test.Equal(t, len(lines), 3)
+
+	for _, line := range lines {
+		test.True(t, strings.Contains(line, want), test.Context("line %q missing %q", line, want))
+	}
+}
+
+func TestPrimitiveValues(t *testing.T) {
+	hue.Enabled(false) // Force no color
+
+	// Primitives are appended straight into the line, which must match how slog formats them
+	attrs := []slog.Attr{
+		slog.Int("int", -42),
+		slog.Int64("int64", math.MinInt64),
+		slog.Uint64("uint64", math.MaxUint64),
+		slog.Float64("float", 0.1),
+		slog.Float64("big", 1e21),
+		slog.Float64("small", 1e-7),
+		slog.Float64("nan", math.NaN()),
+		slog.Float64("inf", math.Inf(-1)),
+		slog.Bool("true", true),
+		slog.Bool("false", false),
+	}
+
+	for _, attr := range attrs {
+		t.Run(attr.Key, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			logger := log.New(buf, log.WithPlain())
+
+			logger.Info("Value", attr)
+
+			test.Diff(t, buf.String(), "INFO:  Value "+attr.Key+"="+attr.Value.String()+"\n")
+		})
+	}
+}
+
+func TestStringer(t *testing.T) {
+	hue.Enabled(false)
+
+	fixedTime := func() time.Time {
+		fixed, err := time.Parse(time.RFC3339, "2025-04-01T13:34:03Z")
+		test.Ok(t, err)
+
+		return fixed
+	}
+
+	var nilPoint *point
+
+	tests := []struct {
+		value any    // The value to log
+		name  string // Name of the test case
+		want  string // Expected log line
+	}{
+		{
+			name:  "struct",
+			value: point{X: 1, Y: 2},
+			want:  "2025-04-01T13:34:03Z INFO:  Moved to=\"(1, 2)\"\n",
+		},
+		{
+			name:  "pointer",
+			value: &point{X: 3, Y: 4},
+			want:  "2025-04-01T13:34:03Z INFO:  Moved to=\"(3, 4)\"\n",
+		},
+		{
+			name:  "nil pointer",
+			value: nilPoint,
+			want:  "2025-04-01T13:34:03Z INFO:  Moved to=<nil>\n",
+		},
+		{
+			name:  "list",
+			value: path{"a", "b", "c"},
+			want:  "2025-04-01T13:34:03Z INFO:  Moved to=a/b/c\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			logger := log.New(buf, log.TimeFunc(fixedTime), log.WithListFormat(log.ListComma))
+
+			logger.Info("Moved", slog.Any("to", tt.value))
+
+			test.Diff(t, buf.String(), tt.want)
+		})
+	}
+}
+
+func TestTrailingAttrs(t *testing.T) {
+	hue.Enabled(false)
+
+	fixedTime := func() time.Time {
+		fixed, err := time.Parse(time.RFC3339, "2025-04-01T13:34:03Z")
+		test.Ok(t, err)
+
+		return fixed
+	}
+
+	tests := []struct {
+		name    string       // Name of the test case
+		want    string       // Expected log line
+		options []log.Option // Extra options to configure the logger
+	}{
+		{
+			name:    "default",
+			options: nil,
+			want:    "2025-04-01T13:34:03Z INFO:  msg user=tom status=200 agent=curl version=1.2.3 host=box\n",
+		},
+		{
+			name:    "alphabetical",
+			options: []log.Option{log.WithAttrLayout(log.Alphabetical)},
+			want:    "2025-04-01T13:34:03Z INFO:  msg agent=curl status=200 user=tom version=1.2.3 host=box\n",
+		},
+		{
+			name:    "persistent first",
+			options: []log.Option{log.WithAttrLayout(log.PersistentFirst)},
+			want:    "2025-04-01T13:34:03Z INFO:  msg user=tom agent=curl status=200 version=1.2.3 host=box\n",
+		},
+		{
+			name:    "json",
+			options: []log.Option{log.WithJSON()},
+			want: `{"time":"2025-04-01T13:34:03Z","level":"INFO","msg":"msg","user":"tom",` +
+				`"status":200,"agent":"curl","version":"1.2.3","host":"box"}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+
+			options := append(
+				[]log.Option{
+					log.TimeFunc(fixedTime),
+					log.WithTrailingAttrs(slog.String("version", "1.2.3"), slog.String("host", "box")),
+				},
+				tt.options...,
+			)
+
+			logger := log.New(buf, options...).With(slog.String("user", "tom"))
+
+			logger.Info("msg", slog.Int("status", 200), slog.String("agent", "curl"))
+
+			test.Diff(t, buf.String(), tt.want)
+		})
+	}
+}
+
+func TestMessageColumn(t *testing.T) {
+	hue.Enabled(false)
+
+	fixedTime := func() time.Time {
+		fixed, err := time.Parse(time.RFC3339, "2025-04-01T13:34:03Z")
+		test.Ok(t, err)
+
+		return fixed
+	}
+
+	tests := []struct {
+		name    string       // Name of the test case
+		want    string       // Expected output
+		options []log.Option // Extra options to configure the logger
+		column  int          // The message column
+	}{
+		{
+			name:   "padded",
+			column: 40,
+			want: "2025-04-01T13:34:03Z INFO:              Message key=value\n" +
+				"2025-04-01T13:34:03Z ERROR db:          Message key=value\n" +
+				"2025-04-01T13:34:03Z WARN scheduler:    Message key=value\n" +
+				"2025-04-01T13:34:03Z INFO http.server…: Message key=value\n",
+		},
+		{
+			name:    "colour",
+			column:  40,
+			options: []log.Option{log.WithColor(log.ColorAlways)},
+			want: "\x1b[2m2025-04-01T13:34:03Z\x1b[0m \x1b[1;36mINFO\x1b[0m:              Message \x1b[35mkey\x1b[0m=value\n" +
+				"\x1b[2m2025-04-01T13:34:03Z\x1b[0m \x1b[1;31mERROR\x1b[0m \x1b[1;2mdb\x1b[0m:          Message \x1b[35mkey\x1b[0m=value\n" +
+				"\x1b[2m2025-04-01T13:34:03Z\x1b[0m \x1b[1;33mWARN\x1b[0m \x1b[1;2mscheduler\x1b[0m:    Message \x1b[35mkey\x1b[0m=value\n" +
+				"\x1b[2m2025-04-01T13:34:03Z\x1b[0m \x1b[1;36mINFO\x1b[0m \x1b[1;2mhttp.server…\x1b[0m: Message \x1b[35mkey\x1b[0m=value\n",
+		},
+		{
+			name:    "plain",
+			column:  16,
+			options: []log.Option{log.WithPlain()},
+			want: "INFO:           Message key=value\n" +
+				"ERROR db:       Message key=value\n" +
+				"WARN scheduler: Message key=value\n" +
+				"INFO http.ser…: Message key=value\n",
+		},
+		{
+			name:   "too small",
+			column: 10,
+			want: "2025-04-01T13:34:03Z INFO: Message key=value\n" +
+				"2025-04-01T13:34:03Z ERROR db: Message key=value\n" +
+				"2025-04-01T13:34:03Z WARN scheduler: Message key=value\n" +
+				"2025-04-01T13:34:03Z INFO http.server.v2: Message key=value\n",
+		},
+		{
+			name:   "disabled",
+			column: 0,
+			want: "2025-04-01T13:34:03Z INFO:  Message key=value\n" +
+				"2025-04-01T13:34:03Z ERROR db: Message key=value\n" +
+				"2025-04-01T13:34:03Z WARN scheduler:  Message key=value\n" +
+				"2025-04-01T13:34:03Z INFO http.server.v2:  Message key=value\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+
+			options := append([]log.Option{log.TimeFunc(fixedTime), log.WithMessageColumn(tt.column)}, tt.options...)
+
+			logger := log.New(buf, options...)
+
+			logger.Info("Message", slog.String("key", "value"))
+			logger.Prefixed("db").Error("Message", slog.String("key", "value"))
+			logger.Prefixed("scheduler").Warn("Message", slog.String("key", "value"))
+			logger.Prefixed("http.server.v2").Info("Message", slog.String("key", "value"))
+
+			test.Diff(t, buf.String(), tt.want)
+		})
+	}
+}
+
+func TestDynamicAttrs(t *testing.T) {
+	hue.Enabled(false)
+
+	fixedTime := func() time.Time {
+		fixed, err := time.Parse(time.RFC3339, "2025-04-01T13:34:03Z")
+		test.Ok(t, err)
+
+		return fixed
+	}
+
+	buf := &bytes.Buffer{}
+
+	calls := 0
+	dynamic := func() []slog.Attr {
+		calls++
+		return []slog.Attr{slog.Int("call", calls)}
+	}
+
+	logger := log.New(
+		buf,
+		log.TimeFunc(fixedTime),
+		log.WithDynamicAttrs(dynamic),
+		log.WithTrailingAttrs(slog.String("version", "1.2.3")),
+	).With(slog.String("user", "tom"))
+
+	logger.Debug("Filtered")
+	test.Equal(t, calls, 0, test.Context("dynamic attrs called for a filtered line"))
+
+	logger.Info("One", slog.Int("status", 200))
+	logger.Warn("Two")
+	test.Equal(t, calls, 2)
+
+	want := "2025-04-01T13:34:03Z INFO:  One user=tom status=200 call=1 version=1.2.3\n" +
+		"2025-04-01T13:34:03Z WARN:  Two user=tom call=2 version=1.2.3\n"
+
+	test.Diff(t, buf.String(), want)
+
+	t.Run("json", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		logger := log.New(buf, log.TimeFunc(fixedTime), log.WithJSON(), log.WithDynamicAttrs(func() []slog.Attr {
+			return []slog.Attr{slog.String("request", "abc")}
+		}))
+
+		logger.Info("Hello")
+
+		test.Diff(t, buf.String(), `{"time":"2025-04-01T13:34:03Z","level":"INFO","msg":"Hello","request":"abc"}`+"\n")
+	})
+}
+
+func TestMaxAttrs(t *testing.T) {
+	hue.Enabled(false)
+
+	fixedTime := func() time.Time {
+		fixed, err := time.Parse(time.RFC3339, "2025-04-01T13:34:03Z")
+		test.Ok(t, err)
+
+		return fixed
+	}
+
+	tests := []struct {
+		name    string       // Name of the test case
+		want    string       // Expected log line
+		options []log.Option // Extra options to configure the logger
+	}{
+		{
+			name:    "unlimited",
+			options: nil,
+			want:    "2025-04-01T13:34:03Z INFO:  msg user=tom id=1 a=1 b=2 c=3\n",
+		},
+		{
+			name:    "persistent counted first",
+			options: []log.Option{log.WithMaxAttrs(3)},
+			want:    "2025-04-01T13:34:03Z INFO:  msg user=tom id=1 a=1 …(+2 more)\n",
+		},
+		{
+			name:    "only persistent",
+			options: []log.Option{log.WithMaxAttrs(1)},
+			want:    "2025-04-01T13:34:03Z INFO:  msg user=tom …(+4 more)\n",
+		},
+		{
+			name:    "exactly enough",
+			options: []log.Option{log.WithMaxAttrs(5)},
+			want:    "2025-04-01T13:34:03Z INFO:  msg user=tom id=1 a=1 b=2 c=3\n",
+		},
+		{
+			name:    "negative",
+			options: []log.Option{log.WithMaxAttrs(-1)},
+			want:    "2025-04-01T13:34:03Z INFO:  msg user=tom id=1 a=1 b=2 c=3\n",
+		},
+		{
+			name:    "colour",
+			options: []log.Option{log.WithMaxAttrs(4), log.WithColor(log.ColorAlways)},
+			want: "\x1b[2m2025-04-01T13:34:03Z\x1b[0m \x1b[1;36mINFO\x1b[0m:  msg \x1b[35muser\x1b[0m=tom " +
+				"\x1b[35mid\x1b[0m=1 \x1b[35ma\x1b[0m=1 \x1b[35mb\x1b[0m=2 \x1b[2m…(+1 more)\x1b[0m\n",
+		},
+		{
+			name:    "json not truncated",
+			options: []log.Option{log.WithMaxAttrs(1), log.WithJSON()},
+			want: `{"time":"2025-04-01T13:34:03Z","level":"INFO","msg":"msg","user":"tom",` +
+				`"id":1,"a":1,"b":2,"c":3}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+
+			options := append([]log.Option{log.TimeFunc(fixedTime)}, tt.options...)
+
+			logger := log.New(buf, options...).With(slog.String("user", "tom"), slog.Int("id", 1))
+
+			logger.Info("msg", slog.Int("a", 1), slog.Int("b", 2), slog.Int("c", 3))
+
+			test.Diff(t, buf.String(), tt.want)
+		})
+	}
+}
+
+func TestAttrSections(t *testing.T) {
+	hue.Enabled(false)
+
+	fixedTime := func() time.Time {
+		fixed, err := time.Parse(time.RFC3339, "2025-04-01T13:34:03Z")
+		test.Ok(t, err)
+
+		return fixed
+	}
+
+	tests := []struct {
+		name       string       // Name of the test case
+		want       string       // Expected log line
+		options    []log.Option // Extra options to configure the logger
+		persistent []slog.Attr  // Persistent attrs to add with With
+	}{
+		{
+			name:       "basic",
+			options:    nil,
+			persistent: []slog.Attr{slog.String("id", "42"), slog.String("user", "tom")},
+			want:       "2025-04-01T13:34:03Z INFO:  Handled [id=42 user=tom] status=200\n",
+		},
+		{
+			name:       "single",
+			options:    nil,
+			persistent: []slog.Attr{slog.String("id", "42")},
+			want:       "2025-04-01T13:34:03Z INFO:  Handled [id=42] status=200\n",
+		},
+		{
+			name:       "no persistent",
+			options:    nil,
+			persistent: nil,
+			want:       "2025-04-01T13:34:03Z INFO:  Handled status=200\n",
+		},
+		{
+			name:       "max attrs",
+			options:    []log.Option{log.WithMaxAttrs(1)},
+			persistent: []slog.Attr{slog.String("id", "42"), slog.String("user", "tom")},
+			want:       "2025-04-01T13:34:03Z INFO:  Handled [id=42] …(+2 more)\n",
+		},
+		{
+			name:       "last rendered nothing",
+			options:    []log.Option{log.WithFlagStyleBools()},
+			persistent: []slog.Attr{slog.String("id", "42"), slog.Bool("admin", false)},
+			want:       "2025-04-01T13:34:03Z INFO:  Handled [id=42] status=200\n",
+		},
+		{
+			name:       "colour",
+			options:    []log.Option{log.WithColor(log.ColorAlways)},
+			persistent: []slog.Attr{slog.String("id", "42")},
+			want: "\x1b[2m2025-04-01T13:34:03Z\x1b[0m \x1b[1;36mINFO\x1b[0m:  Handled \x1b[2m[\x1b[0m\x1b[35mid\x1b[0m=42" +
+				"\x1b[2m]\x1b[0m \x1b[35mstatus\x1b[0m=200\n",
+		},
+		{
+			name:       "json",
+			options:    []log.Option{log.WithJSON()},
+			persistent: []slog.Attr{slog.String("id", "42")},
+			want:       `{"time":"2025-04-01T13:34:03Z","level":"INFO","msg":"Handled","id":"42","status":200}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+
+			options := append([]log.Option{log.TimeFunc(fixedTime), log.WithAttrSections()}, tt.options...)
+
+			logger := log.New(buf, options...).With(tt.persistent...)
+
+			logger.Info("Handled", slog.Int("status", 200))
+
+			test.Diff(t, buf.String(), tt.want)
+		})
+	}
+}
+
+func TestAttrs(t *testing.T) {
+	hue.Enabled(false) // Force no color
+
+	buf := &bytes.Buffer{}
+	logger := log.New(buf, log.TimeFormat(time.Kitchen)).With(slog.String("a", "1")).With(slog.Int("b", 2))
+
+	attrs := logger.Attrs()
+	test.Equal(t, len(attrs), 2)
+	test.True(t, attrs[0].Equal(slog.String("a", "1")), test.Context("wrong first attr: %v", attrs[0]))
+	test.True(t, attrs[1].Equal(slog.Int("b", 2)), test.Context("wrong second attr: %v", attrs[1]))
+
+	// Mutating the copy must not leak back into the logger
+	attrs[0] = slog.String("a", "changed")
+	_ = append(attrs[:1], slog.String("c", "3"))
+
+	logger.Info("Hello")
+	test.True(t, strings.HasSuffix(buf.String(), "Hello a=1 b=2\n"), test.Context("got %q", buf.String()))
+
+	test.Equal(t, len(log.New(buf).Attrs()), 0, test.Context("expected no attrs on a fresh logger"))
+}
+
+func TestDebugAttrs(t *testing.T) {
+	t.Run("chain", func(t *testing.T) {
+		logs := &bytes.Buffer{}
+		logger := log.New(logs, log.WithLevel(log.LevelDebug), log.WithColor(log.ColorAlways)).
+			With(slog.String("service", "oven")).
+			Prefixed("http").
+			With(slog.String("request id", "abc"), slog.Duration("timeout", 5*time.Second))
+
+		buf := &bytes.Buffer{}
+		logger.DebugAttrs(buf)
+
+		want := "prefix: http\n" +
+			"level:  DEBUG\n" +
+			"attrs:\n" +
+			"  service=oven\n" +
+			"  \"request id\"=abc\n" +
+			"  timeout=5s\n"
+
+		test.Diff(t, buf.String(), want)
+		test.Equal(t, logs.String(), "", test.Context("DebugAttrs should not go through the log path"))
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		log.New(io.Discard, log.WithTrailingAttrs(slog.Int("pid", 1))).DebugAttrs(buf)
+
+		want := "prefix: (none)\n" +
+			"level:  INFO\n" +
+			"attrs: (none)\n" +
+			"trailing attrs:\n" +
+			"  pid=1\n"
+
+		test.Diff(t, buf.String(), want)
+	})
+}
+
+func TestRecover(t *testing.T) {
+	hue.Enabled(false)
+
+	buf := &flushWriter{}
+	logger := log.New(buf)
+
+	crash := func() {
+		defer logger.Recover()
+
+		panic("boom")
+	}
+
+	// The panic must still propagate after being logged
+	recovered := func() (value any) {
+		defer func() { value = recover() }()
+
+		crash()
+
+		return nil
+	}()
+
+	test.Equal(t, recovered, any("boom"))
+	test.Equal(t, buf.flushed, 1, test.Context("expected the logger to be synced"))
+
+	got := buf.String()
+	test.True(t, strings.Contains(got, "ERROR: panic panic=boom stack="), test.Context("missing panic line: %q", got))
+	test.True(t, strings.Contains(got, "TestRecover"), test.Context("missing stack trace: %q", got))
+
+	// Nothing happens without a panic
+	buf.Reset()
+
+	func() {
+		defer logger.Recover()
+	}()
+
+	test.Equal(t, buf.Len(), 0)
+}
+
+func TestSync(t *testing.T) {
+	t.Run("flusher", func(t *testing.T) {
+		w := &flushWriter{}
+		logger := log.New(w)
+
+		logger.Info("Hello")
+		test.Ok(t, logger.Sync())
+		test.Equal(t, w.flushed, 1, test.Context("expected Flush to be called once"))
+	})
+
+	t.Run("syncer", func(t *testing.T) {
+		w := &syncWriter{}
+		logger := log.New(w)
+
+		logger.Info("Hello")
+		test.Ok(t, logger.Sync())
+		test.Equal(t, w.synced, 1, test.Context("expected Sync to be called once"))
+	})
+
+	t.Run("error", func(t *testing.T) {
+		w := &syncWriter{err: errors.New("disk full")}
+		logger := log.New(w)
+
+		err := logger.Sync()
+		test.Err(t, err)
+		test.Equal(t, err.Error(), "disk full")
+	})
+
+	t.Run("neither", func(t *testing.T) {
+		logger := log.New(&bytes.Buffer{})
+		test.Ok(t, logger.Sync())
+	})
+}
+
+func TestLevelFromVerbosity(t *testing.T) {
+	tests := []struct {
+		name    string    // Name of the test case
+		verbose int       // Number of -v flags
+		quiet   int       // Number of -q flags
+		want    log.Level // Expected level
+	}{
+		{name: "default", verbose: 0, quiet: 0, want: log.LevelInfo},
+		{name: "verbose", verbose: 1, quiet: 0, want: log.LevelDebug},
+		{name: "very verbose", verbose: 2, quiet: 0, want: log.LevelDebug},
+		{name: "clamped verbose", verbose: 10, quiet: 0, want: log.LevelDebug},
+		{name: "quiet", verbose: 0, quiet: 1, want: log.LevelWarn},
+		{name: "very quiet", verbose: 0, quiet: 2, want: log.LevelError},
+		{name: "clamped quiet", verbose: 0, quiet: 10, want: log.LevelError},
+		{name: "cancel out", verbose: 1, quiet: 1, want: log.LevelInfo},
+		{name: "net quiet", verbose: 1, quiet: 2, want: log.LevelWarn},
+		{name: "negative", verbose: -3, quiet: 0, want: log.LevelInfo},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test.Equal(t, log.LevelFromVerbosity(tt.verbose, tt.quiet), tt.want)
+		})
+	}
+}
+
+func TestErrorHandler(t *testing.T) {
+	var errs []error
+
+	handler := func(err error) {
+		errs = append(errs, err)
+	}
+
+	w := &errWriter{err: errors.New("broken pipe")}
+	logger := log.New(w, log.WithErrorHandler(handler))
+
+	logger.Info("One")
+	logger.Prefixed("sub").Info("Two")
+	logger.Debug("Filtered out, never written")
+
+	test.Equal(t, len(errs), 2, test.Context("expected the handler to be called for every failed write"))
+
+	for _, err := range errs {
+		test.Equal(t, err.Error(), "broken pipe")
+	}
+
+	// Without a handler, errors are silently ignored
+	log.New(w).Info("Nothing happens")
+}
+
+func TestGoroutineID(t *testing.T) {
+	hue.Enabled(false) // Force no color
+
+	// goroutineIDs returns the goroutine IDs from each line in the log output
+	goroutineIDs := func(t *testing.T, output string) []string {
+		t.Helper()
+
+		var ids []string
+
+		for line := range strings.Lines(output) {
+			_, id, ok := strings.Cut(strings.TrimSpace(line), " goroutine=")
+			test.True(t, ok, test.Context("line %q has no goroutine ID", line))
+
+			ids = append(ids, id)
+		}
+
+		return ids
+	}
+
+	buf := &bytes.Buffer{}
+	logger := log.New(buf, log.WithGoroutineID())
+
+	logger.Info("One", slog.String("key", "value"))
+	logger.Info("Two")
+
+	var wg sync.WaitGroup
+
+	wg.Go(func() {
+		logger.Info("Three")
+	})
+
+	wg.Wait()
+
+	ids := goroutineIDs(t, buf.String())
+	test.Equal(t, len(ids), 3)
+
+	test.Equal(t, ids[0], ids[1], test.Context("same goroutine should have the same ID"))
+	test.NotEqual(t, ids[0], ids[2], test.Context("different goroutines should have different IDs"))
+	test.NotEqual(t, ids[0], "0", test.Context("goroutine ID should have been found"))
+}
+
+func TestCaller(t *testing.T) {
+	hue.Enabled(false) // Force no color
+
+	// nextLine returns the location of the line after the one calling it, as the logger
+	// would show it
+	nextLine := func() string {
+		_, file, line, ok := runtime.Caller(1)
+		test.True(t, ok, test.Context("could not get caller"))
+
+		return fmt.Sprintf("%s:%d", filepath.Base(file), line+1)
+	}
+
+	t.Run("default", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		logger := log.New(buf, log.WithPlain(), log.WithCaller())
+
+		want := nextLine()
+		logger.Info("Hello")
+
+		test.Diff(t, buf.String(), "INFO:  Hello source="+want+"\n")
+	})
+
+	t.Run("key", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		logger := log.New(buf, log.WithPlain(), log.WithCaller(), log.WithCallerKey("caller"))
+
+		want := nextLine()
+		logger.Prefixed("sub").Warn("Hello", slog.Int("n", 1))
+
+		test.Diff(t, buf.String(), "WARN sub:  Hello n=1 caller="+want+"\n")
+	})
+
+	t.Run("slog", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		logger := slog.New(log.New(buf, log.WithPlain(), log.WithCaller(), log.WithCallerKey("@caller")).Handler())
+
+		want := nextLine()
+		logger.Info("Hello")
+
+		test.Diff(t, buf.String(), "INFO:  Hello @caller="+want+"\n")
+	})
+
+	t.Run("timer", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		logger := log.New(buf, log.WithPlain(), log.WithCaller(), log.TimeFunc(func() time.Time { return time.Time{} }))
+
+		stop := logger.Timer("Querying")
+
+		want := nextLine()
+		stop()
+
+		test.Diff(t, buf.String(), "INFO:  Querying duration=0s source="+want+"\n")
+	})
+
+	t.Run("key alone", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		logger := log.New(buf, log.WithPlain(), log.WithCallerKey("caller"))
+
+		logger.Info("Hello")
+
+		test.Diff(t, buf.String(), "INFO:  Hello\n")
+	})
+}
+
+func TestBind(t *testing.T) {
+	outer := log.New(io.Discard).Prefixed("outer")
+	inner := outer.Prefixed("inner")
+
+	unbindOuter := log.Bind(outer)
+	test.Equal(t, log.Current(), outer)
+
+	unbindInner := log.Bind(inner)
+	test.Equal(t, log.Current(), inner)
+
+	var wg sync.WaitGroup
+
+	wg.Go(func() {
+		test.NotEqual(t, log.Current(), inner, test.Context("binding leaked to another goroutine"))
+	})
+
+	wg.Wait()
+
+	unbindInner()
+	test.Equal(t, log.Current(), outer, test.Context("unbinding should restore the previous logger"))
+
+	unbindOuter()
+	test.NotEqual(t, log.Current(), outer, test.Context("unbinding should remove the logger"))
+	test.NotEqual(t, log.Current(), nil)
+}
+
+func TestUnbuffered(t *testing.T) {
+	hue.Enabled(true) // Force colour, escape codes must come through unchanged
+	t.Cleanup(func() { hue.Enabled(false) })
+
+	// Constantly return the same time
+	fixedTime := func() time.Time {
+		fixed, err := time.Parse(time.RFC3339, "2025-04-01T13:34:03Z")
+		test.Ok(t, err)
+
+		return fixed
+	}
+
+	logLine := func(logger *log.Logger) {
+		logger.Prefixed("oven").Warn(
+			"Pizza is burning!",
+			slog.String("flavour", "pepperoni"),
+			slog.Duration("cooked", 20*time.Minute),
+		)
+	}
+
+	buffered := &countWriter{}
+	logLine(log.New(buffered, log.TimeFunc(fixedTime)))
+
+	unbuffered := &countWriter{}
+	logLine(log.New(unbuffered, log.TimeFunc(fixedTime), log.WithUnbuffered()))
+
+	test.Diff(t, unbuffered.String(), buffered.String())
+	test.Equal(t, buffered.writes, 1, test.Context("buffered logger should write once per line"))
+
+	// Timestamp, level, prefix, message, 2 attrs and the trailing newline
+	test.Equal(t, unbuffered.writes, 7, test.Context("unbuffered logger should write once per component"))
+}
+
+func TestRace(t *testing.T) {
+	buf := &bytes.Buffer{}
+
+	// Constantly return the same time
+	fixedTime := func() time.Time {
+		fixed, err := time.Parse(time.RFC3339, "2025-04-01T13:34:03Z")
+		test.Ok(t, err)
+
+		return fixed
+	}
+
+	logger := log.New(buf, log.TimeFunc(fixedTime))
+	sub := logger.Prefixed("sub")
+
+	const n = 1000
+
+	var wg sync.WaitGroup
+	wg.Add(n)
+
+	for i := range n {
+		go func(wg *sync.WaitGroup, i int) {
+			defer wg.Done()
+
+			logger.Info(fmt.Sprintf("Something: %d", i))
+		}(&wg, i)
+	}
+
+	wg.Add(n)
+
+	for i := range n {
+		go func(wg *sync.WaitGroup, i int) {
+			defer wg.Done()
+
+			sub.Info(fmt.Sprintf("Other: %d", i))
+		}(&wg, i)
+	}
+
+	wg.Wait()
+
+	// Make sure they all got written, order doesn't matter because concurrency
+	got := strings.TrimSpace(buf.String())
+	lines := strings.Split(got, "\n")
+
+	test.Equal(t, len(lines), n*2, test.Context("expected %d log lines", n*2))
+}
+
+func TestSequenceNumbers(t *testing.T) {
+	hue.Enabled(false)
+
+	buf := &bytes.Buffer{}
+	logger := log.New(buf, log.WithSequenceNumbers())
+	sub := logger.Prefixed("sub")
+
+	const (
+		goroutines = 50
+		perRoutine = 20
+	)
+
+	logger.Debug("Filtered, doesn't use a number")
+
+	var wg sync.WaitGroup
+
+	for g := range goroutines {
+		wg.Go(func() {
+			for i := range perRoutine {
+				if g%2 == 0 {
+					logger.Info("Line", slog.Int("g", g), slog.Int("i", i))
+				} else {
+					sub.Info("Line", slog.Int("g", g), slog.Int("i", i))
+				}
+			}
+		})
+	}
+
+	wg.Wait()
+
+	pattern := regexp.MustCompile(`g=(\d+) i=(\d+) seq=(\d+)$`)
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	test.Equal(t, len(lines), goroutines*perRoutine)
+
+	seen := make(map[int]bool)
+	last := make(map[int]int) // Last sequence number seen for each goroutine
+
+	for _, line := range lines {
+		match := pattern.FindStringSubmatch(line)
+		test.True(t, match != nil, test.Context("line %q doesn't end with a sequence number", line))
+
+		g, err := strconv.Atoi(match[1])
+		test.Ok(t, err)
+
+		seq, err := strconv.Atoi(match[3])
+		test.Ok(t, err)
+
+		test.False(t, seen[seq], test.Context("duplicate sequence number %d", seq))
+		seen[seq] = true
+
+		// Each goroutine logs in order so its numbers must only go up
+		test.True(t, seq > last[g], test.Context("goroutine %d: seq %d after %d", g, seq, last[g]))
+		last[g] = seq
+	}
+
+	// Every number from 1 to the number of lines is used exactly once
+	for seq := 1; seq <= goroutines*perRoutine; seq++ {
+		test.True(t, seen[seq], test.Context("missing sequence number %d", seq))
+	}
+}
+
+// TestAllocs pins the number of allocations on the hot path so that changes to
+// the logger can't silently make it slower.
+//
+// The one allocation on the enabled path is the timestamp scratch buffer escaping
+// to the heap when it's styled. If a change does reduce allocations, lower the
+// target here so it can't creep back up.
+func TestAllocs(t *testing.T) {
+	hue.Enabled(true) // Force colour, styling must not allocate either
+
+	buf := &bytes.Buffer{}
+	logger := log.New(buf)
+	prefixed := logger.Prefixed("bench")
+	persistent := logger.With(slog.String("service", "oven"))
+	discard := log.New(io.Discard)
+
+	tests := []struct {
+		fn   func()  // The log call to measure
+		name string  // Name of the test case
+		max  float64 // Maximum allowed allocations per call
+	}{
+		{
+			name: "enabled",
+			max:  1,
+			fn:   func() { logger.Info("A message!") },
+		},
+		{
+			name: "prefixed",
+			max:  1,
+			fn:   func() { prefixed.Info("A message!") },
+		},
+		{
+			name: "attrs",
+			max:  1,
+			fn: func() {
+				logger.Info(
+					"A message!",
+					slog.Int("status", http.StatusOK),
+					slog.Duration("duration", 57*time.Millisecond),
+					slog.String("sentence", "has spaces"),
+					slog.Bool("ok", true),
+					slog.Float64("ratio", 0.5),
+				)
+			},
+		},
+		{
+			name: "primitive attrs",
+			max:  1,
+			fn: func() {
+				logger.Info(
+					"A message!",
+					slog.Int("count", -42),
+					slog.Uint64("size", math.MaxUint64),
+					slog.Float64("ratio", 0.75),
+					slog.Bool("ok", true),
+				)
+			},
+		},
+		{
+			name: "persistent attrs",
+			max:  1,
+			fn:   func() { persistent.Info("A message!", slog.Int("status", http.StatusOK)) },
+		},
+		{
+			name: "disabled",
+			max:  0,
+			fn:   func() { logger.Debug("A message!") },
+		},
+		{
+			name: "discard",
+			max:  0,
+			fn:   func() { discard.Info("A message!") },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			allocs := testing.AllocsPerRun(100, func() {
+				buf.Reset()
+				tt.fn()
+			})
+
+			test.True(t, allocs <= tt.max, test.Context("got %v allocations per call, target is %v", allocs, tt.max))
+		})
+	}
+}
+
+func TestBufferHint(t *testing.T) {
+	wide := make([]slog.Attr, 0, 32)
+	for i := range 32 {
+		wide = append(wide, slog.String(fmt.Sprintf("key%d", i), "a reasonably long value"))
+	}
+
+	// allocs returns the allocations of a wide line rendered into a fresh buffer
+	allocs := func(hint int) float64 {
+		logger := log.New(&bytes.Buffer{}, log.WithBufferHint(hint))
+
+		return testing.AllocsPerRun(100, func() {
+			log.EmptyBufferPool()
+			logger.Info("A message!", wide...)
+		})
+	}
+
+	unhinted, hinted := allocs(0), allocs(2048)
+
+	test.True(t, hinted < unhinted, test.Context("hinted buffer allocated %v times, unhinted %v", hinted, unhinted))
+}
+
+func TestBufferStrategy(t *testing.T) {
+	hue.Enabled(false) // Force no color
+
+	fixedTime := func() time.Time {
+		return time.Date(2025, time.April, 1, 13, 34, 3, 0, time.UTC)
+	}
+
+	buf := &bytes.Buffer{}
+	logger := log.New(buf, log.TimeFunc(fixedTime), log.WithBufferStrategy(log.FreeList(2)))
+
+	logger.Info("A long message that fills the buffer", slog.String("key", "a long value"))
+	test.Equal(t, log.FreeListLen(logger), 1, test.Context("buffer not returned to the free list"))
+
+	// The reused buffer must have been reset
+	logger.Prefixed("child").Info("Short")
+	test.Equal(t, log.FreeListLen(logger), 1, test.Context("buffer not reused"))
+
+	want := "2025-04-01T13:34:03Z INFO:  A long message that fills the buffer key=\"a long value\"\n" +
+		"2025-04-01T13:34:03Z INFO child:  Short\n"
+
+	test.Diff(t, buf.String(), want)
+
+	// Many concurrent lines must still only keep as many buffers as asked for
+	concurrent := log.New(io.Discard, log.WithCapture(), log.WithBufferStrategy(log.FreeList(2)), log.WithPlain())
+
+	var wg sync.WaitGroup
+	for i := range 50 {
+		wg.Go(func() {
+			concurrent.Info("Line", slog.Int("i", i))
+		})
+	}
+
+	wg.Wait()
+
+	test.True(t, log.FreeListLen(concurrent) <= 2, test.Context("free list grew beyond its size"))
+
+	lines := concurrent.Captured()
+	test.Equal(t, len(lines), 50)
+
+	for _, line := range lines {
+		test.True(t, strings.HasPrefix(line, "INFO:  Line i="), test.Context("corrupted line %q", line))
+	}
+}
+
+func TestRender(t *testing.T) {
+	fixedTime := func() time.Time {
+		fixed, err := time.Parse(time.RFC3339, "2025-04-01T13:34:03Z")
+		test.Ok(t, err)
+
+		return fixed
+	}
+
+	tests := []struct {
+		name    string       // Name of the test case
+		options []log.Option // Options to configure the logger
+	}{
+		{
+			name:    "default",
+			options: nil,
+		},
+		{
+			name:    "prefix",
+			options: []log.Option{log.Prefix("cooking")},
+		},
+		{
+			name:    "colour",
+			options: []log.Option{log.WithColor(log.ColorAlways)},
+		},
+		{
+			name:    "json",
+			options: []log.Option{log.WithJSON()},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := tt.fn()
-			got = strings.ReplaceAll(got, fixedTimeString, "[TIME]")
-			test.Diff(t, got, tt.want)
+			hue.Enabled(false)
+
+			buf := &bytes.Buffer{}
+
+			logger := log.New(buf, append(tt.options, log.TimeFunc(fixedTime))...)
+
+			attrs := []slog.Attr{slog.String("flavour", "pepperoni"), slog.Int("slices", 8)}
+
+			got := logger.Render(log.LevelInfo, "Pizza is ready", attrs...)
+			test.Equal(t, buf.Len(), 0, test.Context("Render should not write anything"))
+
+			logger.Info("Pizza is ready", attrs...)
+
+			test.Diff(t, got, buf.String())
 		})
 	}
+
+	t.Run("no level filtering", func(t *testing.T) {
+		hue.Enabled(false)
+
+		logger := log.New(io.Discard, log.TimeFunc(fixedTime), log.WithLevel(log.LevelError))
+
+		got := logger.Render(log.LevelDebug, "Hidden")
+		test.Equal(t, got, "2025-04-01T13:34:03Z DEBUG: Hidden\n")
+	})
 }
 
-func TestRace(t *testing.T) {
+func TestRaw(t *testing.T) {
+	hue.Enabled(false)
+
 	buf := &bytes.Buffer{}
 
-	// Constantly return the same time
+	// Normal log lines and raw lines should never be interleaved mid-line
+	logger := log.New(buf, log.WithLevel(log.LevelError))
+
+	const n = 500
+
+	raw := []byte(strings.Repeat("raw", 100) + "\n")
+
+	var wg sync.WaitGroup
+
+	for i := range n {
+		wg.Go(func() {
+			logger.Raw(raw)
+		})
+
+		wg.Go(func() {
+			// Filtered by level but raw bypasses that, so only the raw ones show up
+			logger.Info(fmt.Sprintf("Filtered: %d", i))
+			logger.Error(fmt.Sprintf("Logged: %d", i))
+		})
+	}
+
+	wg.Wait()
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	test.Equal(t, len(lines), n*2, test.Context("expected %d lines", n*2))
+
+	for _, line := range lines {
+		if strings.HasPrefix(line, "raw") {
+			test.Equal(t, line+"\n", string(raw), test.Context("raw line was interleaved"))
+			continue
+		}
+
+		test.True(t, strings.Contains(line, "ERROR: Logged: "), test.Context("unexpected line: %q", line))
+	}
+
+	// Raw respects discard
+	log.New(io.Discard).Raw(raw)
+}
+
+func TestCapture(t *testing.T) {
+	hue.Enabled(false)
+
 	fixedTime := func() time.Time {
 		fixed, err := time.Parse(time.RFC3339, "2025-04-01T13:34:03Z")
 		test.Ok(t, err)
@@ -273,39 +2873,226 @@ func TestRace(t *testing.T) {
 		return fixed
 	}
 
-	logger := log.New(buf, log.TimeFunc(fixedTime))
-	sub := logger.Prefixed("sub")
+	buf := &bytes.Buffer{}
+	logger := log.New(buf, log.TimeFunc(fixedTime), log.WithCapture())
 
-	const n = 1000
+	logger.Debug("Filtered")
+	logger.Info("Would delete", slog.String("file", "a.txt"))
+	logger.Prefixed("sub").Warn("Would skip")
+	logger.Separator()
 
-	var wg sync.WaitGroup
-	wg.Add(n)
+	test.Equal(t, buf.Len(), 0, test.Context("nothing should be written in capture mode"))
 
-	for i := range n {
-		go func(wg *sync.WaitGroup, i int) {
-			defer wg.Done()
+	want := []string{
+		"2025-04-01T13:34:03Z INFO:  Would delete file=a.txt",
+		"2025-04-01T13:34:03Z WARN sub:  Would skip",
+		"",
+	}
 
-			logger.Info(fmt.Sprintf("Something: %d", i))
-		}(&wg, i)
+	test.EqualFunc(t, logger.Captured(), want, slices.Equal)
+
+	// Returned slice is a copy
+	logger.Captured()[0] = "changed"
+	test.Equal(t, logger.Captured()[0], want[0])
+
+	// Works with a discard writer too
+	discard := log.New(io.Discard, log.WithCapture())
+	discard.Info("Kept")
+	test.Equal(t, len(discard.Captured()), 1)
+
+	// Not capturing
+	test.Equal(t, len(log.New(buf).Captured()), 0)
+
+	t.Run("concurrent", func(t *testing.T) {
+		logger := log.New(io.Discard, log.WithCapture())
+
+		var wg sync.WaitGroup
+		for range 100 {
+			wg.Go(func() { logger.Info("Hello") })
+		}
+
+		wg.Wait()
+
+		test.Equal(t, len(logger.Captured()), 100)
+	})
+}
+
+func TestBanner(t *testing.T) {
+	hue.Enabled(false)
+
+	t.Run("not a terminal", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		log.New(buf, log.WithLevel(log.LevelError)).Banner("mytool v1.2.3 starting")
+
+		test.Equal(t, buf.String(), "mytool v1.2.3 starting\n")
+	})
+
+	t.Run("unknown width", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		log.FakeTerminal(t, buf)
+		log.New(buf).Banner("starting")
+
+		test.Equal(t, buf.String(), "starting\n")
+	})
+
+	t.Run("terminal", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		log.FakeTerminalWidth(t, buf, 20)
+		log.New(buf).Banner("starting")
+
+		rule := strings.Repeat("─", 20)
+		want := rule + "\n" + "      starting\n" + rule + "\n"
+
+		test.Equal(t, buf.String(), want)
+	})
+
+	t.Run("terminal separator rune", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		log.FakeTerminalWidth(t, buf, 10)
+		log.New(buf, log.WithSeparatorRune('=')).Banner("done")
+
+		want := "==========\n   done\n==========\n"
+
+		test.Equal(t, buf.String(), want)
+	})
+
+	t.Run("terminal colour", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		log.FakeTerminalWidth(t, buf, 10)
+		log.New(buf, log.WithColor(log.ColorAlways)).Banner("done")
+
+		rule := "\x1b[2m" + strings.Repeat("─", 10) + "\x1b[0m"
+		want := rule + "\n   \x1b[1mdone\x1b[0m\n" + rule + "\n"
+
+		test.Equal(t, buf.String(), want)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		log.New(buf, log.WithJSON()).Banner("starting")
+
+		test.Equal(t, buf.Len(), 0)
+	})
+}
+
+func TestNamed(t *testing.T) {
+	hue.Enabled(false)
+
+	fixedTime := func() time.Time {
+		fixed, err := time.Parse(time.RFC3339, "2025-04-01T13:34:03Z")
+		test.Ok(t, err)
+
+		return fixed
 	}
 
-	wg.Add(n)
+	tests := []struct {
+		name    string       // Name of the test case
+		want    string       // Expected log output
+		options []log.Option // Extra options to configure the logger
+	}{
+		{
+			name:    "default",
+			options: nil,
+			want: "2025-04-01T13:34:03Z INFO server.http:  Listening\n" +
+				"2025-04-01T13:34:03Z INFO server.http:  Request request.method=GET\n" +
+				"2025-04-01T13:34:03Z INFO server.http:  Request request.headers.accept=*/*\n",
+		},
+		{
+			name:    "colons",
+			options: []log.Option{log.WithDelimiter("::")},
+			want: "2025-04-01T13:34:03Z INFO server::http:  Listening\n" +
+				"2025-04-01T13:34:03Z INFO server::http:  Request request::method=GET\n" +
+				"2025-04-01T13:34:03Z INFO server::http:  Request request::headers::accept=*/*\n",
+		},
+		{
+			name:    "slash",
+			options: []log.Option{log.WithDelimiter("/")},
+			want: "2025-04-01T13:34:03Z INFO server/http:  Listening\n" +
+				"2025-04-01T13:34:03Z INFO server/http:  Request request/method=GET\n" +
+				"2025-04-01T13:34:03Z INFO server/http:  Request request/headers/accept=*/*\n",
+		},
+		{
+			name:    "empty ignored",
+			options: []log.Option{log.WithDelimiter("")},
+			want: "2025-04-01T13:34:03Z INFO server.http:  Listening\n" +
+				"2025-04-01T13:34:03Z INFO server.http:  Request request.method=GET\n" +
+				"2025-04-01T13:34:03Z INFO server.http:  Request request.headers.accept=*/*\n",
+		},
+	}
 
-	for i := range n {
-		go func(wg *sync.WaitGroup, i int) {
-			defer wg.Done()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
 
-			sub.Info(fmt.Sprintf("Other: %d", i))
-		}(&wg, i)
+			options := append([]log.Option{log.TimeFunc(fixedTime)}, tt.options...)
+
+			logger := log.New(buf, options...).Named("server").Named("http")
+			logger.Info("Listening")
+
+			request := slog.New(logger.Handler()).WithGroup("request")
+			request.Info("Request", slog.String("method", "GET"))
+			request.WithGroup("headers").Info("Request", slog.String("accept", "*/*"))
+
+			test.Diff(t, buf.String(), tt.want)
+		})
 	}
+}
 
-	wg.Wait()
+func TestSeparator(t *testing.T) {
+	hue.Enabled(false)
 
-	// Make sure they all got written, order doesn't matter because concurrency
-	got := strings.TrimSpace(buf.String())
-	lines := strings.Split(got, "\n")
+	t.Run("blank", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		log.New(buf).Separator()
 
-	test.Equal(t, len(lines), n*2, test.Context("expected %d log lines", n*2))
+		test.Equal(t, buf.String(), "\n")
+	})
+
+	t.Run("rule", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		log.New(buf, log.WithSeparatorRune('─')).Separator()
+
+		test.Equal(t, buf.String(), strings.Repeat("─", 80)+"\n")
+	})
+
+	t.Run("rule colour", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		log.New(buf, log.WithSeparatorRune('='), log.WithColor(log.ColorAlways)).Separator()
+
+		test.Equal(t, buf.String(), "\x1b[2m"+strings.Repeat("=", 80)+"\x1b[0m\n")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		log.New(buf, log.WithJSON()).Separator()
+
+		test.Equal(t, buf.Len(), 0)
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		logger := log.New(buf, log.WithSeparatorRune('-'))
+
+		const n = 200
+
+		var wg sync.WaitGroup
+
+		for range n {
+			wg.Go(logger.Separator)
+			wg.Go(func() { logger.Info("Hello") })
+		}
+
+		wg.Wait()
+
+		rule := strings.Repeat("-", 80)
+
+		lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+		test.Equal(t, len(lines), n*2)
+
+		for _, line := range lines {
+			test.True(t, line == rule || strings.HasSuffix(line, " INFO:  Hello"), test.Context("interleaved line: %q", line))
+		}
+	})
 }
 
 func BenchmarkLogger(b *testing.B) {
@@ -354,6 +3141,22 @@ func BenchmarkLogger(b *testing.B) {
 		buf.Reset()
 	})
 
+	b.Run("primitive_attrs", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for b.Loop() {
+			debugLogger.Debug(
+				"A message!",
+				slog.Int("count", -42),
+				slog.Uint64("size", 1024),
+				slog.Float64("ratio", 0.75),
+				slog.Bool("ok", true),
+			)
+		}
+
+		buf.Reset()
+	})
+
 	b.Run("persistent_attrs", func(b *testing.B) {
 		for b.Loop() {
 			attrLogger.Debug("A message!", slog.Int("status", http.StatusOK))
@@ -370,6 +3173,27 @@ func BenchmarkLogger(b *testing.B) {
 		buf.Reset()
 	})
 
+	// A line wider than a freshly pooled buffer, with and without a buffer hint. The hint
+	// only matters when the buffer is new so the pool is emptied every iteration, a warm
+	// pool would already hold a buffer grown to fit
+	wide := make([]slog.Attr, 0, 32)
+	for i := range 32 {
+		wide = append(wide, slog.String(fmt.Sprintf("key%d", i), "a reasonably long value"))
+	}
+
+	for _, hint := range []int{0, 2048} {
+		logger := log.New(buf, log.WithLevel(log.LevelDebug), log.WithBufferHint(hint))
+
+		b.Run(fmt.Sprintf("wide_hint_%d", hint), func(b *testing.B) {
+			for b.Loop() {
+				log.EmptyBufferPool()
+				logger.Debug("A message!", wide...)
+			}
+
+			buf.Reset()
+		})
+	}
+
 	b.Run("discard", func(b *testing.B) {
 		// Here to test that effectively nothing is done
 		// when w == io.Discard
@@ -381,9 +3205,183 @@ func BenchmarkLogger(b *testing.B) {
 	})
 }
 
+func BenchmarkBufferStrategy(b *testing.B) {
+	// Lines of varying width so buffers grow to different sizes
+	attrs := make([]slog.Attr, 0, 16)
+	for i := range 16 {
+		attrs = append(attrs, slog.String(fmt.Sprintf("key%d", i), "a reasonably long value"))
+	}
+
+	strategies := []struct {
+		name     string
+		strategy log.BufferStrategy
+	}{
+		{name: "pool", strategy: log.PoolUnbounded},
+		{name: "free_list_4", strategy: log.FreeList(4)},
+		{name: "free_list_64", strategy: log.FreeList(64)},
+	}
+
+	for _, tt := range strategies {
+		logger := log.New(discardWriter{}, log.WithBufferStrategy(tt.strategy))
+
+		b.Run(tt.name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.RunParallel(func(pb *testing.PB) {
+				i := 0
+				for pb.Next() {
+					logger.Info("A message!", attrs[:i%len(attrs)]...)
+					i++
+				}
+			})
+		})
+	}
+}
+
+// BenchmarkThroughput compares the throughput of a realistic, attr heavy and prefixed log
+// line across common configurations, reporting lines and bytes per second so the cost
+// of each option can be weighed up, e.g.
+//
+//	go test -run '^$' -bench Throughput
+func BenchmarkThroughput(b *testing.B) {
+	hue.Enabled(true) // Force colour, so ColorAlways below is honoured
+
+	attrs := []slog.Attr{
+		slog.String("method", http.MethodGet),
+		slog.String("path", "/api/v1/pizzas"),
+		slog.Int("status", http.StatusOK),
+		slog.Duration("duration", 57*time.Millisecond),
+		slog.Int("bytes", 1024),
+		slog.String("agent", "Mozilla/5.0 (X11; Linux x86_64)"),
+	}
+
+	configurations := []struct {
+		name    string       // Name of the configuration
+		options []log.Option // Options to configure the logger with
+	}{
+		{name: "colour", options: []log.Option{log.WithColor(log.ColorAlways)}},
+		{name: "no_colour", options: []log.Option{log.WithColor(log.ColorNever)}},
+		{name: "json", options: []log.Option{log.WithJSON()}},
+		{name: "unbuffered", options: []log.Option{log.WithColor(log.ColorAlways), log.WithUnbuffered()}},
+		{name: "free_list", options: []log.Option{log.WithColor(log.ColorAlways), log.WithBufferStrategy(log.FreeList(8))}},
+	}
+
+	for _, tt := range configurations {
+		logger := log.New(discardWriter{}, tt.options...).
+			Prefixed("server").
+			With(slog.String("request_id", "b7f3c2a1"), slog.String("user", "tom"))
+
+		b.Run(tt.name, func(b *testing.B) {
+			// Every line is the same length so one rendered ahead of time will do
+			b.SetBytes(int64(len(logger.Render(log.LevelInfo, "Handled request", attrs...))))
+			b.ReportAllocs()
+
+			for b.Loop() {
+				logger.Info("Handled request", attrs...)
+			}
+
+			b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "lines/s")
+		})
+	}
+}
+
+// discardWriter is an [io.Writer] that discards everything, without the logger
+// recognising it as [io.Discard] and skipping the work.
+type discardWriter struct{}
+
+func (discardWriter) Write(p []byte) (int, error) {
+	return len(p), nil
+}
+
+// point is a [fmt.Stringer] with a friendlier form than Go syntax.
+type point struct {
+	X, Y int
+}
+
+func (p point) String() string {
+	return fmt.Sprintf("(%d, %d)", p.X, p.Y)
+}
+
+// path is a list type that implements [fmt.Stringer].
+type path []string
+
+func (p path) String() string {
+	return strings.Join(p, "/")
+}
+
 // secret is a [slog.LogValuer] that redacts its value when logged.
 type secret string
 
 func (s secret) LogValue() slog.Value {
 	return slog.StringValue("REDACTED")
 }
+
+// flushWriter is an [io.Writer] that implements [log.Flusher] only.
+type flushWriter struct {
+	bytes.Buffer
+
+	flushed int
+}
+
+func (f *flushWriter) Flush() error {
+	f.flushed++
+	return nil
+}
+
+// syncWriter is an [io.Writer] that implements [log.Syncer] only.
+type syncWriter struct {
+	err error
+	bytes.Buffer
+
+	synced int
+}
+
+func (s *syncWriter) Sync() error {
+	s.synced++
+	return s.err
+}
+
+// errWriter is an [io.Writer] whose writes always fail.
+type errWriter struct {
+	err error
+}
+
+func (e *errWriter) Write(p []byte) (int, error) {
+	return 0, e.err
+}
+
+// countWriter is an [io.Writer] that counts the number of calls to Write.
+type countWriter struct {
+	bytes.Buffer
+
+	writes int
+}
+
+func (c *countWriter) Write(p []byte) (int, error) {
+	c.writes++
+	return c.Buffer.Write(p)
+}
+
+func TestHeartbeat(t *testing.T) {
+	logger := log.New(io.Discard, log.WithCapture(), log.WithLevel(log.LevelDebug), log.WithPlain())
+
+	stop := logger.Heartbeat(time.Millisecond)
+
+	// Wait for a few heartbeats
+	for len(logger.Captured()) < 3 {
+		time.Sleep(time.Millisecond)
+	}
+
+	stop()
+	stop() // Must be safe to call again
+
+	beats := logger.Captured()
+	for _, line := range beats {
+		test.Equal(t, line, "DEBUG: heartbeat")
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	test.Equal(t, len(logger.Captured()), len(beats), test.Context("heartbeat written after stop"))
+
+	// A discarded logger mustn't start anything, but stop must still work
+	log.New(io.Discard).Heartbeat(time.Millisecond)()
+}
@@ -0,0 +1,247 @@
+// Package logtest provides a [Recorder] for asserting on the output of a [log.Logger] in tests,
+// without parsing rendered text or fixing timestamps.
+//
+//	func TestSomething(t *testing.T) {
+//		rec := logtest.New()
+//
+//		doSomething(rec.Logger())
+//
+//		rec.AssertLogged(t, log.LevelInfo, "Downloaded")
+//		rec.AssertAttr(t, "status", 200)
+//	}
+package logtest
+
+import (
+	"fmt"
+	"log/slog"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"go.followtheprocess.codes/log"
+)
+
+// bufferSize is the size of the channel records are buffered in until a [Recorder]
+// collects them. A test logging more lines than this between calls to the [Recorder] fails.
+const bufferSize = 4096
+
+// Recorder records the structured log lines written by its logger, see [New].
+//
+// It is safe to use across concurrently executing goroutines.
+type Recorder struct {
+	logger  *log.Logger
+	records chan log.Record
+	seen    []log.Record // Records collected from the channel so far
+	dropped atomic.Int64 // Number of records dropped because the channel was full
+	resets  int          // Number of calls to Reset, so offsets into seen can tell they're stale
+	mu      sync.Mutex   // Protects seen and resets
+}
+
+// New returns a new [Recorder], whose logger is configured with options.
+//
+// The logger records each log line as a [log.Record] rather than writing it, so the
+// formatting options have no effect but level filtering, persistent attrs, prefixes and
+// so on all apply as normal. Any handler set with [log.WithErrorHandler] is replaced.
+func New(options ...log.Option) *Recorder {
+	rec := &Recorder{
+		records: make(chan log.Record, bufferSize),
+	}
+
+	options = append(slices.Clone(options), log.WithErrorHandler(func(error) {
+		rec.dropped.Add(1)
+	}))
+
+	rec.logger = log.NewChannel(rec.records, options...)
+
+	return rec
+}
+
+// Logger returns the logger that writes to the Recorder.
+func (r *Recorder) Logger() *log.Logger {
+	return r.logger
+}
+
+// Records returns a copy of every log line recorded so far, in the order they were logged.
+func (r *Recorder) Records() []log.Record {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.collect()
+
+	return slices.Clone(r.seen)
+}
+
+// Dropped returns the number of log lines dropped so far because more than 4096 were
+// logged between calls to the Recorder. The assertions fail the test if any were.
+func (r *Recorder) Dropped() int {
+	return int(r.dropped.Load())
+}
+
+// Reset discards every log line recorded so far.
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.collect()
+	r.seen = nil
+	r.resets++
+}
+
+// AssertLogged fails the test if no log line was recorded at level with a message
+// containing msg.
+func (r *Recorder) AssertLogged(tb testing.TB, level log.Level, msg string) {
+	tb.Helper()
+	r.checkDropped(tb)
+
+	for _, record := range r.Records() {
+		if record.Level == level && strings.Contains(record.Message, msg) {
+			return
+		}
+	}
+
+	tb.Errorf("logtest: no %s log with a message containing %q\n\n%s", level, msg, r.summary())
+}
+
+// AssertNotLogged fails the test if a log line was recorded at level with a message
+// containing msg.
+func (r *Recorder) AssertNotLogged(tb testing.TB, level log.Level, msg string) {
+	tb.Helper()
+	r.checkDropped(tb)
+
+	for _, record := range r.Records() {
+		if record.Level == level && strings.Contains(record.Message, msg) {
+			tb.Errorf("logtest: unexpected %s log with a message containing %q\n\n%s", level, msg, r.summary())
+			return
+		}
+	}
+}
+
+// AssertAttr fails the test if no log line was recorded with an attr with the given
+// key and value. Values are compared as [slog.Value]s, so an int value matches an
+// attr created with [slog.Int] and so on.
+func (r *Recorder) AssertAttr(tb testing.TB, key string, value any) {
+	tb.Helper()
+	r.checkDropped(tb)
+
+	want := slog.AnyValue(value).Resolve()
+
+	for _, record := range r.Records() {
+		for _, attr := range record.Attrs {
+			if attr.Key == key && equal(attr.Value.Resolve(), want) {
+				return
+			}
+		}
+	}
+
+	tb.Errorf("logtest: no log with attr %s=%v\n\n%s", key, want, r.summary())
+}
+
+// ExpectNoneAbove returns a function that fails the test if any log line at or above
+// level was recorded between the call to ExpectNoneAbove and the call to the function,
+// enforcing that a code path doesn't e.g. warn:
+//
+//	defer rec.ExpectNoneAbove(t, log.LevelWarn)()
+//	doSomething(rec.Logger())
+//
+// Lines recorded before ExpectNoneAbove was called are ignored. Calling [Recorder.Reset]
+// within the scope means every line recorded since is checked.
+func (r *Recorder) ExpectNoneAbove(tb testing.TB, level log.Level) func() {
+	tb.Helper()
+
+	r.mu.Lock()
+	r.collect()
+	start := len(r.seen)
+	resets := r.resets
+	r.mu.Unlock()
+
+	return func() {
+		tb.Helper()
+		r.checkDropped(tb)
+
+		r.mu.Lock()
+		r.collect()
+
+		// Everything before start has been discarded if there's been a Reset since
+		if r.resets != resets {
+			start = 0
+		}
+
+		records := slices.Clone(r.seen[start:])
+		r.mu.Unlock()
+
+		var found []log.Record
+
+		for _, record := range records {
+			if record.Level >= level {
+				found = append(found, record)
+			}
+		}
+
+		if len(found) > 0 {
+			tb.Errorf("logtest: %d unexpected log lines at or above %s\n\n%s", len(found), level, r.summary())
+		}
+	}
+}
+
+// checkDropped fails the test if any log lines have been dropped.
+func (r *Recorder) checkDropped(tb testing.TB) {
+	tb.Helper()
+
+	if dropped := r.Dropped(); dropped > 0 {
+		tb.Errorf("logtest: %d log lines were dropped, more than %d were logged between calls to the Recorder", dropped, bufferSize)
+	}
+}
+
+// collect moves any records waiting in the channel into seen. The caller must hold r.mu.
+func (r *Recorder) collect() {
+	for {
+		select {
+		case record := <-r.records:
+			r.seen = append(r.seen, record)
+		default:
+			return
+		}
+	}
+}
+
+// summary returns a description of what was recorded, for failure messages.
+func (r *Recorder) summary() string {
+	records := r.Records()
+	if len(records) == 0 {
+		return "nothing was logged"
+	}
+
+	s := &strings.Builder{}
+	s.WriteString("logged:\n")
+
+	for _, record := range records {
+		fmt.Fprintf(s, "\t%s", record.Level)
+
+		if record.Prefix != "" {
+			fmt.Fprintf(s, " %s", record.Prefix)
+		}
+
+		fmt.Fprintf(s, ": %s", record.Message)
+
+		for _, attr := range record.Attrs {
+			fmt.Fprintf(s, " %s", attr)
+		}
+
+		s.WriteByte('\n')
+	}
+
+	return s.String()
+}
+
+// equal reports whether two resolved values are equal, comparing values of
+// [slog.KindAny] deeply as they may not be comparable with ==.
+func equal(got, want slog.Value) bool {
+	if got.Kind() == slog.KindAny && want.Kind() == slog.KindAny {
+		return reflect.DeepEqual(got.Any(), want.Any())
+	}
+
+	return got.Equal(want)
+}
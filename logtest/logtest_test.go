@@ -0,0 +1,163 @@
+package logtest_test
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"go.followtheprocess.codes/hue"
+	"go.followtheprocess.codes/log"
+	"go.followtheprocess.codes/log/logtest"
+	"go.followtheprocess.codes/test"
+)
+
+// fakeTB is a [testing.TB] that records failures rather than failing the real test.
+type fakeTB struct {
+	testing.TB
+
+	failures []string // The messages of each failure
+}
+
+func (f *fakeTB) Helper() {}
+
+func (f *fakeTB) Errorf(format string, args ...any) {
+	f.failures = append(f.failures, fmt.Sprintf(format, args...))
+}
+
+func TestRecorder(t *testing.T) {
+	hue.Enabled(false) // Force no color
+
+	rec := logtest.New(log.WithLevel(log.LevelDebug))
+	logger := rec.Logger()
+
+	logger.Debug("Resolving dependencies")
+	logger.Prefixed("http").Info("Downloaded", slog.Int("status", 200), slog.Duration("took", time.Second))
+	logger.With(slog.String("user", "tom")).Warn("Cache miss", slog.Any("keys", []string{"a", "b"}))
+
+	records := rec.Records()
+	test.Equal(t, len(records), 3)
+	test.Equal(t, records[1].Prefix, "http")
+	test.Equal(t, records[1].Message, "Downloaded")
+
+	t.Run("pass", func(t *testing.T) {
+		tb := &fakeTB{TB: t}
+
+		rec.AssertLogged(tb, log.LevelDebug, "dependencies")
+		rec.AssertLogged(tb, log.LevelInfo, "Downloaded")
+		rec.AssertNotLogged(tb, log.LevelError, "Downloaded")
+		rec.AssertAttr(tb, "status", 200)
+		rec.AssertAttr(tb, "took", time.Second)
+		rec.AssertAttr(tb, "user", "tom")
+		rec.AssertAttr(tb, "keys", []string{"a", "b"})
+
+		test.Equal(t, len(tb.failures), 0, test.Context("unexpected failures: %v", tb.failures))
+	})
+
+	t.Run("fail", func(t *testing.T) {
+		tb := &fakeTB{TB: t}
+
+		rec.AssertLogged(tb, log.LevelError, "Downloaded")
+		rec.AssertNotLogged(tb, log.LevelWarn, "Cache")
+		rec.AssertAttr(tb, "status", 404)
+		rec.AssertAttr(tb, "missing", true)
+
+		test.Equal(t, len(tb.failures), 4)
+
+		want := "logtest: no ERROR log with a message containing \"Downloaded\"\n\n" +
+			"logged:\n" +
+			"\tDEBUG: Resolving dependencies\n" +
+			"\tINFO http: Downloaded status=200 took=1s\n" +
+			"\tWARN: Cache miss user=tom keys=[a b]\n"
+
+		test.Diff(t, tb.failures[0], want)
+		test.True(t, strings.HasPrefix(tb.failures[2], "logtest: no log with attr status=404"))
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		rec.Reset()
+		test.Equal(t, len(rec.Records()), 0)
+
+		tb := &fakeTB{TB: t}
+		rec.AssertLogged(tb, log.LevelInfo, "Downloaded")
+
+		test.Equal(t, len(tb.failures), 1)
+		test.True(t, strings.HasSuffix(tb.failures[0], "nothing was logged"))
+	})
+}
+
+func TestRecorderFiltered(t *testing.T) {
+	rec := logtest.New(log.WithLevel(log.LevelWarn))
+
+	rec.Logger().Info("Filtered")
+	rec.Logger().Error("Kept")
+
+	rec.AssertNotLogged(t, log.LevelInfo, "Filtered")
+	rec.AssertLogged(t, log.LevelError, "Kept")
+}
+
+func TestExpectNoneAbove(t *testing.T) {
+	t.Run("pass", func(t *testing.T) {
+		rec := logtest.New()
+		rec.Logger().Error("Before the scope")
+
+		tb := &fakeTB{TB: t}
+		check := rec.ExpectNoneAbove(tb, log.LevelWarn)
+
+		rec.Logger().Info("Fine")
+
+		check()
+
+		test.Equal(t, len(tb.failures), 0, test.Context("unexpected failures: %v", tb.failures))
+	})
+
+	t.Run("fail", func(t *testing.T) {
+		rec := logtest.New()
+
+		tb := &fakeTB{TB: t}
+		check := rec.ExpectNoneAbove(tb, log.LevelWarn)
+
+		rec.Logger().Info("Fine")
+		rec.Logger().Warn("Cache miss")
+		rec.Logger().Error("Broken")
+
+		check()
+
+		test.Equal(t, len(tb.failures), 1)
+		test.True(t, strings.HasPrefix(tb.failures[0], "logtest: 2 unexpected log lines at or above WARN\n\nlogged:\n"))
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		rec := logtest.New()
+		rec.Logger().Info("Before the scope")
+		rec.Logger().Info("Also before the scope")
+
+		tb := &fakeTB{TB: t}
+		check := rec.ExpectNoneAbove(tb, log.LevelWarn)
+
+		rec.Reset()
+		rec.Logger().Warn("Cache miss")
+
+		check()
+
+		test.Equal(t, len(tb.failures), 1)
+		test.True(t, strings.HasPrefix(tb.failures[0], "logtest: 1 unexpected log lines at or above WARN\n\nlogged:\n"))
+	})
+}
+
+func TestRecorderDropped(t *testing.T) {
+	rec := logtest.New()
+
+	for range 4100 {
+		rec.Logger().Info("Spam")
+	}
+
+	test.Equal(t, rec.Dropped(), 4)
+
+	tb := &fakeTB{TB: t}
+	rec.AssertLogged(tb, log.LevelInfo, "Spam")
+
+	test.Equal(t, len(tb.failures), 1)
+	test.True(t, strings.Contains(tb.failures[0], "4 log lines were dropped"))
+}
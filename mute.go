@@ -0,0 +1,69 @@
+package log
+
+import (
+	"maps"
+	"sync/atomic"
+)
+
+// mutedPrefixes is the set of prefixes muted with [Logger.MutePrefix], shared by a
+// logger and all those derived from it.
+//
+// Logging far outnumbers muting so the set is copied on write, leaving lookups
+// lock and allocation free.
+type mutedPrefixes struct {
+	set atomic.Pointer[map[string]struct{}]
+}
+
+// MutePrefix suppresses every log line from loggers with the given prefix, regardless
+// of level, until it's unmuted with [Logger.UnmutePrefix]. This lets e.g. an interactive
+// tool hide the lines from a noisy subsystem at runtime:
+//
+//	logger.MutePrefix("cache") // Nothing from logger.Prefixed("cache") is shown
+//
+// The prefix must match exactly, so muting "server" doesn't mute "server.http". Like
+// [Logger.SetColor], muting applies to the logger and every logger sharing its lineage
+// and is safe to call concurrently with logging.
+func (l *Logger) MutePrefix(prefix string) {
+	levelOverridden.Store(true)
+	l.muted.update(func(set map[string]struct{}) {
+		set[prefix] = struct{}{}
+	})
+}
+
+// UnmutePrefix undoes [Logger.MutePrefix], it does nothing if prefix isn't muted.
+func (l *Logger) UnmutePrefix(prefix string) {
+	l.muted.update(func(set map[string]struct{}) {
+		delete(set, prefix)
+	})
+}
+
+// update replaces the set with a modified copy.
+func (m *mutedPrefixes) update(modify func(set map[string]struct{})) {
+	for {
+		old := m.set.Load()
+
+		updated := make(map[string]struct{})
+		if old != nil {
+			maps.Copy(updated, *old)
+		}
+
+		modify(updated)
+
+		if m.set.CompareAndSwap(old, &updated) {
+			return
+		}
+	}
+}
+
+// contains reports whether prefix is muted.
+func (m *mutedPrefixes) contains(prefix []byte) bool {
+	set := m.set.Load()
+	if set == nil || len(*set) == 0 {
+		return false
+	}
+
+	// The compiler optimises away the string conversion in a map lookup
+	_, ok := (*set)[string(prefix)]
+
+	return ok
+}
@@ -0,0 +1,42 @@
+package log
+
+import "sync"
+
+// onceKeys holds the keys passed to [Logger.Once] that have already logged.
+//
+//nolint:gochecknoglobals // Process wide by design
+var onceKeys sync.Map
+
+// Once returns a new [Logger] that writes at most one log line for key for the
+// lifetime of the process, no matter how often it's called, e.g. for deprecation
+// notices that would otherwise be repeated every time a code path runs:
+//
+//	for _, file := range files {
+//		if file.UsesOldSyntax() {
+//			logger.Once("old-syntax").Warn("The old syntax is deprecated")
+//		}
+//	}
+//
+// Keys are shared by every logger in the process, so keys from different packages
+// should be distinct. Lines filtered out by level don't count as the one line for their
+// key. The returned logger is otherwise an exact clone of the caller.
+func (l *Logger) Once(key string) *Logger {
+	sub := l.clone()
+
+	sub.once = key
+
+	return sub
+}
+
+// claimOnce reports whether the logger may write a line, which is always true unless
+// it was created with [Logger.Once], in which case only the first call for its key
+// in the process returns true.
+func (l *Logger) claimOnce() bool {
+	if l.once == "" {
+		return true
+	}
+
+	_, logged := onceKeys.LoadOrStore(l.once, struct{}{})
+
+	return !logged
+}
@@ -0,0 +1,79 @@
+package log_test
+
+import (
+	"bytes"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"go.followtheprocess.codes/hue"
+	"go.followtheprocess.codes/log"
+	"go.followtheprocess.codes/test"
+)
+
+func TestOnce(t *testing.T) {
+	hue.Enabled(false) // Force no color
+	log.ResetOnce(t)
+
+	fixedTime := func() time.Time {
+		return time.Date(2025, time.April, 1, 13, 34, 3, 0, time.UTC)
+	}
+
+	buf := &bytes.Buffer{}
+	logger := log.New(buf, log.TimeFunc(fixedTime))
+
+	for range 3 {
+		// Filtered lines must not use up the key
+		logger.Once("test-once-filtered").Debug("Filtered")
+		logger.Once("test-once-deprecated").Warn("Deprecated", slog.String("flag", "--old"))
+		logger.Once("test-once-config").Info("Config fallback")
+	}
+
+	logger.Once("test-once-filtered").Info("Not filtered")
+
+	want := "2025-04-01T13:34:03Z WARN:  Deprecated flag=--old\n" +
+		"2025-04-01T13:34:03Z INFO:  Config fallback\n" +
+		"2025-04-01T13:34:03Z INFO:  Not filtered\n"
+
+	test.Diff(t, buf.String(), want)
+}
+
+func TestOnceDump(t *testing.T) {
+	hue.Enabled(false) // Force no color
+	log.ResetOnce(t)
+
+	fixedTime := func() time.Time {
+		return time.Date(2025, time.April, 1, 13, 34, 3, 0, time.UTC)
+	}
+
+	buf := &bytes.Buffer{}
+	logger := log.New(buf, log.TimeFunc(fixedTime), log.WithLevel(log.LevelDebug))
+
+	for range 3 {
+		logger.Once("test-once-dump").Dump("Port", 8080)
+	}
+
+	want := "2025-04-01T13:34:03Z DEBUG: Port\n" +
+		"    8080\n"
+
+	test.Diff(t, buf.String(), want)
+}
+
+func TestOnceConcurrent(t *testing.T) {
+	log.ResetOnce(t)
+
+	buf := &bytes.Buffer{}
+	logger := log.New(buf, log.WithJSON())
+
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Go(func() {
+			logger.Once("test-once-concurrent").Info("Only once")
+		})
+	}
+
+	wg.Wait()
+
+	test.Equal(t, bytes.Count(buf.Bytes(), []byte("\n")), 1)
+}
@@ -1,6 +1,15 @@
 package log
 
-import "time"
+import (
+	"log/slog"
+	"maps"
+	"os"
+	"slices"
+	"sync/atomic"
+	"time"
+
+	"go.followtheprocess.codes/hue"
+)
 
 // Option is a functional option for configuring a [Logger].
 type Option func(*Logger)
@@ -12,12 +21,80 @@ func WithLevel(level Level) Option {
 	}
 }
 
+// WithClampLevel bounds the effective minimum level of the logger to between lo and hi
+// inclusive, whatever it's configured to be by [WithLevel], [WithPrefixLevel],
+// [WithLevelContext] or [Logger.Apply].
+//
+// This lets a library hand out a logger that can't be made too verbose or too quiet by
+// the application using it, e.g. one that always shows warnings and errors but never
+// debug lines:
+//
+//	logger := log.New(os.Stderr, log.WithClampLevel(log.LevelInfo, log.LevelWarn))
+//
+// If lo is greater than hi they are swapped. The global minimum level set with
+// [SetGlobalMinLevel] is applied separately and may still hide lines.
+func WithClampLevel(lo, hi Level) Option {
+	return func(l *Logger) {
+		l.clampMin = min(lo, hi)
+		l.clampMax = max(lo, hi)
+		l.clamped = true
+	}
+}
+
 // TimeFormat sets the format of the time information.
 //
 // The layout is the standard Go [time.Format] and defaults to [time.RFC3339].
+// See also [WithTimePreset] for some common formats.
 func TimeFormat(format string) Option {
 	return func(l *Logger) {
 		l.timeFormat = format
+		l.timePreset = PresetRFC3339
+		l.noTimestamp = false
+	}
+}
+
+// WithTimePreset sets the format of the time information to one of a set of
+// common formats, avoiding the need to get a layout string right.
+//
+//	logger := log.New(os.Stderr, log.WithTimePreset(log.PresetTimeOnly))
+//
+// Like [TimeFormat], the last one of these options passed wins.
+func WithTimePreset(preset TimePreset) Option {
+	return func(l *Logger) {
+		l.timePreset = PresetRFC3339
+		l.noTimestamp = false
+
+		switch preset {
+		case PresetRFC3339:
+			l.timeFormat = time.RFC3339
+		case PresetRFC3339Millis:
+			l.timeFormat = rfc3339Millis
+		case PresetKitchen:
+			l.timeFormat = time.Kitchen
+		case PresetDateTime:
+			l.timeFormat = time.DateTime
+		case PresetTimeOnly:
+			l.timeFormat = time.TimeOnly
+		case PresetUnix, PresetISOWeek, PresetOrdinal:
+			l.timePreset = preset
+		}
+	}
+}
+
+// WithHybridTime makes text logs show the time elapsed since the logger was created on
+// debug and info lines, and the full timestamp on warning and error lines:
+//
+//	+1.5s INFO:  Building
+//	2025-04-01T13:34:04Z ERROR: Build failed
+//
+// This keeps the output of long, mostly interactive, runs compact while errors can
+// still be correlated with other systems. The full timestamp uses the format set with
+// [TimeFormat] or [WithTimePreset] ([time.RFC3339] by default), as do JSON logs, which
+// always show the full timestamp.
+func WithHybridTime() Option {
+	return func(l *Logger) {
+		l.hybridTime = true
+		l.noTimestamp = false
 	}
 }
 
@@ -42,3 +119,667 @@ func Prefix(prefix string) Option {
 		l.prefix = []byte(prefix)
 	}
 }
+
+// WithDelimiter sets the delimiter used to join the names of nested loggers created
+// with [Logger.Named], and the names of groups opened on the logger's [Logger.Handler]
+// with [slog.Logger.WithGroup], so both hierarchies look the same:
+//
+//	logger := log.New(os.Stderr, log.WithDelimiter("::"))
+//	logger.Named("server").Named("http").Info("Listening") // Prefix "server::http"
+//
+// The default is ".", an empty delimiter is ignored.
+func WithDelimiter(delimiter string) Option {
+	return func(l *Logger) {
+		if delimiter != "" {
+			l.delimiter = delimiter
+		}
+	}
+}
+
+// WithPrefixLevel sets a minimum level per prefix, overriding [WithLevel] for the logger
+// and any sub loggers (see [Logger.Prefixed]) whose prefix matches a key exactly.
+//
+// Loggers with any other prefix (or no prefix) use the level from [WithLevel].
+//
+//	logger := log.New(os.Stderr, log.WithPrefixLevel(map[string]log.Level{"http": log.LevelDebug}))
+//	logger.Prefixed("http").Debug("Shown")
+//	logger.Debug("Not shown")
+//
+// The map is copied so later changes to it have no effect on the logger.
+func WithPrefixLevel(levels map[string]Level) Option {
+	return func(l *Logger) {
+		l.prefixLevels = maps.Clone(levels)
+	}
+}
+
+// WithSeparatorRune makes [Logger.Separator] draw a dim horizontal rule of r repeated
+// across the full width of the terminal, rather than writing a blank line.
+//
+//	logger := log.New(os.Stderr, log.WithSeparatorRune('─'))
+//
+// If the logger isn't writing to a terminal, the rule is 80 columns wide.
+func WithSeparatorRune(r rune) Option {
+	return func(l *Logger) {
+		l.separator = r
+	}
+}
+
+// WithQuoteChar sets the character text logs quote keys and values with, when they
+// contain whitespace or are empty. The default is a double quote with Go's escaping:
+//
+//	msg="hello \"world\""
+//
+// Any other character only escapes itself and backslashes, e.g. with a single quote,
+// for shell friendly logs:
+//
+//	msg='hello "world"'
+//
+// Non printable characters are always escaped as Go would. Letters, digits, whitespace,
+// backslashes and other non printable runes can't be used, and leave the quote character
+// unchanged. JSON output is unaffected.
+func WithQuoteChar(r rune) Option {
+	return func(l *Logger) {
+		if validQuote(r) {
+			l.quote = r
+		}
+	}
+}
+
+// WithLevelCase sets the letter case of the built in level labels in text output,
+// e.g. [CaseLower] renders "info" rather than "INFO". Colours are unaffected.
+//
+// Custom levels (see [RegisterLevel]) always use their registered name, as does JSON
+// output. Unknown cases are treated as [CaseUpper], the default.
+func WithLevelCase(letterCase LevelCase) Option {
+	return func(l *Logger) {
+		switch letterCase {
+		case CaseLower, CaseTitle:
+			l.levelCase = letterCase
+		default:
+			l.levelCase = CaseUpper
+		}
+	}
+}
+
+// WithCompactLevels renders the level in text output as a single coloured character
+// badge rather than the full label, for very dense output:
+//
+//	2025-04-01T13:34:03Z I: Downloading
+//	2025-04-01T13:34:03Z W: Slow response
+//
+// The badge is the first character of the label, so D, I, W and E for the built in
+// levels (in the case set by [WithLevelCase]) and likewise for custom levels. JSON
+// output is unaffected.
+func WithCompactLevels() Option {
+	return func(l *Logger) {
+		l.compactLevels = true
+	}
+}
+
+// WithLevelColoredPrefix styles the prefix of text log lines in the colour of the line's
+// level, rather than the usual dim bold, so the whole header of e.g. an error line is
+// red and lines of each severity are consistent.
+//
+// Like all other styling, the colours are only shown if colour is enabled.
+func WithLevelColoredPrefix() Option {
+	return func(l *Logger) {
+		l.prefixByLevel = true
+	}
+}
+
+// WithLevelPrefix adds a tag after the level label of lines at the given levels, styled
+// like the label itself, to give particular levels extra emphasis:
+//
+//	logger := log.New(os.Stderr, log.WithLevelPrefix(map[log.Level]string{log.LevelError: "FATAL ERROR"}))
+//	logger.Error("Disk full")
+//
+//	2025-04-01T13:34:03Z ERROR FATAL ERROR: Disk full
+//
+// This is unrelated to the logger's prefix (see [Prefix]), which comes after any tag.
+// Tags are only shown in text output and levels not in the map have no tag. The map is
+// copied so later changes to it have no effect on the logger.
+func WithLevelPrefix(prefixes map[Level]string) Option {
+	return func(l *Logger) {
+		l.levelPrefixes = maps.Clone(prefixes)
+	}
+}
+
+// WithLevelWidth right-pads the level label to width runes, before the colon and any prefix,
+// so that prefixes and messages line up regardless of level:
+//
+//	2025-04-01T13:34:03Z INFO    http: Request
+//	2025-04-01T13:34:03Z WARNING http: Slow request
+//
+// This is mostly useful with custom levels (see [RegisterLevel]) with longer labels than
+// the built in ones. Labels already at least width runes wide are not padded.
+//
+// By default (or if width <= 0) labels are padded after the colon to the width of the
+// longest built in label, so messages line up but prefixes do not.
+func WithLevelWidth(width int) Option {
+	return func(l *Logger) {
+		l.levelWidth = width
+	}
+}
+
+// WithMessageColumn makes every text log line start its message at the given display
+// column, however long its timestamp, level and prefix are, so lines read like a table:
+//
+//	2025-04-01T13:34:03Z INFO db:           Connected
+//	2025-04-01T13:34:03Z WARN scheduler:    Queue is backing up
+//	2025-04-01T13:34:03Z INFO http.server…: Listening
+//
+// Shorter headers are padded out to the column. A prefix that would take the header past
+// it is truncated to fit, ending in an ellipsis. The timestamp and level are never
+// truncated, so if the column is too small for even those the header is left whole
+// and simply isn't padded.
+//
+// By default (or if col <= 0) messages are aligned as described in [WithLevelWidth].
+// JSON output is unaffected.
+func WithMessageColumn(col int) Option {
+	return func(l *Logger) {
+		l.messageColumn = col
+	}
+}
+
+// WithMaxAttrs limits text log lines to n attrs, any more are summarised by a marker
+// so an accidentally huge number of attrs can't produce a runaway line:
+//
+//	2025-04-01T13:34:03Z INFO:  Request method=GET path=/ …(+42 more)
+//
+// Attrs are counted in the order they're rendered: those added with [Logger.With]
+// first, then the per-call attrs, then any from [WithDynamicAttrs], then any from
+// [WithTrailingAttrs] and finally those the logger adds itself: the sequence number
+// from [WithSequenceNumbers], the goroutine ID from [WithGoroutineID] then the caller
+// from [WithCaller]. JSON output is never truncated. By default (or if n <= 0) there
+// is no limit.
+func WithMaxAttrs(n int) Option {
+	return func(l *Logger) {
+		l.maxAttrs = n
+	}
+}
+
+// WithTrailingAttrs sets persistent key value pairs that are rendered at the end of every
+// log line, after the per-call attrs.
+//
+// This is useful for constant context like a version or hostname that should always be
+// in the same place. Whereas attrs added with [Logger.With] come before the per-call
+// attrs, trailing attrs always come after them and are not reordered by [WithAttrLayout].
+// Sub loggers inherit the trailing attrs of their parent.
+func WithTrailingAttrs(attrs ...slog.Attr) Option {
+	return func(l *Logger) {
+		l.trailing = slices.Clone(attrs)
+	}
+}
+
+// WithMessageAsAttr renders the message of text log lines as the first attr, under the
+// key "msg" and quoted if necessary like any other attr value, rather than as free text:
+//
+//	2025-04-01T13:34:03Z INFO:  msg="Pizza is ready" flavour=pepperoni
+//
+// This makes text output more regular for downstream tools to parse while staying
+// readable. JSON output always has the message under "msg" anyway.
+func WithMessageAsAttr() Option {
+	return func(l *Logger) {
+		l.msgAttr = true
+	}
+}
+
+// WithAttrSections sets the persistent attrs of text log lines, those from [Logger.With],
+// apart from the per-call ones by wrapping them in brackets, making it clear which attrs
+// are context and which describe the event:
+//
+//	2025-04-01T13:34:03Z INFO:  Handled request [id=42 user=tom] status=200 duration=5ms
+//
+// The brackets are dimmed when colour is enabled. Lines with no persistent attrs are
+// unaffected, as is JSON output. With [WithAttrLayout] the layout decides the order of
+// all the attrs so there is no section.
+func WithAttrSections() Option {
+	return func(l *Logger) {
+		l.attrSections = true
+	}
+}
+
+// WithFlagStyleBools renders boolean attrs in text output like command line flags: just
+// the key if the value is true (e.g. "verbose" rather than "verbose=true"), and
+// nothing at all if it's false.
+//
+// Note that this means false booleans are dropped from the line entirely. JSON output
+// is unchanged.
+func WithFlagStyleBools() Option {
+	return func(l *Logger) {
+		l.flagBools = true
+	}
+}
+
+// WithSmartPathQuoting renders text attr values that look like file paths or URLs, those
+// starting with "/", "./", "../", "~/" or a URL scheme like "https://", with any spaces
+// and other problem characters percent encoded rather than quoting the whole value. This
+// keeps them clickable in terminals that detect links:
+//
+//	logger.Info("Fetching", slog.String("url", "https://example.com/search?q=deep dish"))
+//	// ... INFO:  Fetching url=https://example.com/search?q=deep%20dish
+//
+// Note that the rendered value is then no longer exactly the original, though it percent
+// decodes back to it, so this is off by default. Other values are quoted as usual and JSON output is unaffected.
+func WithSmartPathQuoting() Option {
+	return func(l *Logger) {
+		l.smartPaths = true
+	}
+}
+
+// WithUnitHints sets units to show after numeric attr values in text output, keyed by
+// attr key, for numbers that carry an implicit unit:
+//
+//	logger := log.New(os.Stderr, log.WithUnitHints(map[string]string{"size": "B", "latency": "ms"}))
+//	logger.Info("Fetched", slog.Int("size", 1024), slog.Int("latency", 57))
+//
+//	2025-04-01T13:34:03Z INFO:  Fetched size=1024B latency=57ms
+//
+// Only int, uint and float valued attrs whose key matches exactly are affected, and JSON
+// output is unchanged. Units are appended as is so should not contain whitespace. The
+// map is copied so later changes to it have no effect on the logger.
+func WithUnitHints(units map[string]string) Option {
+	return func(l *Logger) {
+		l.unitHints = maps.Clone(units)
+	}
+}
+
+// WithValueColorFunc sets a function that chooses the style of attr values in text output,
+// based on the attr's key and (resolved) value. If it returns false the value is rendered
+// as normal.
+//
+// For example, to colour HTTP status codes by class:
+//
+//	log.WithValueColorFunc(func(key string, v slog.Value) (hue.Style, bool) {
+//		if key != "status" || v.Kind() != slog.KindInt64 {
+//			return 0, false
+//		}
+//
+//		switch status := v.Int64(); {
+//		case status >= 500:
+//			return hue.Red, true
+//		case status >= 400:
+//			return hue.Yellow, true
+//		case status >= 300:
+//			return hue.Cyan, true
+//		default:
+//			return hue.Green, true
+//		}
+//	})
+//
+// The function is called for every attr on every log line so it should be fast. The style
+// is subject to the logger's colour mode (see [WithColor]), so nothing is coloured if colour
+// is disabled.
+func WithValueColorFunc(fn func(key string, v slog.Value) (hue.Style, bool)) Option {
+	return func(l *Logger) {
+		l.valueColor = fn
+	}
+}
+
+// WithPunctuationStyle sets the style of the colon ending the header of text log lines,
+// after the timestamp, level and prefix, which is otherwise written plainly. Dimming it
+// to match the timestamp gives a more polished look:
+//
+//	logger := log.New(os.Stderr, log.WithPunctuationStyle(hue.Dim))
+//
+// Like all other styling, the style is only applied if colour is enabled.
+func WithPunctuationStyle(style hue.Style) Option {
+	return func(l *Logger) {
+		l.punctuation = style
+	}
+}
+
+// WithoutLevelLabel omits the level label (e.g. INFO, DEBUG) from every log line.
+//
+// Lines are rendered as "timestamp: message key=value", or "timestamp prefix: message key=value"
+// if a prefix is set. Levels are still used for filtering.
+func WithoutLevelLabel() Option {
+	return func(l *Logger) {
+		l.noLevel = true
+	}
+}
+
+// WithPlain makes the logger write clean, unadorned text for environments that reject
+// any formatting or add their own timestamps, such as CI logs:
+//
+//	INFO:  Building key=value
+//
+// It's shorthand for [WithColor] with [ColorNever], without [WithTerminalClearLine],
+// and with the timestamp left out. To keep the timestamp, pass [TimeFormat] or
+// [WithTimePreset] after it. Level filtering, prefixes and so on work as normal.
+func WithPlain() Option {
+	return func(l *Logger) {
+		l.SetColor(ColorNever)
+		l.clearLine = false
+		l.noTimestamp = true
+	}
+}
+
+// WithService sets the name of the service or application doing the logging, for
+// aggregating logs from many of them.
+//
+// In JSON logs it's included in every record under the reserved key "service", always
+// straight after the level. Text logs show it as the prefix, unless the logger has a
+// prefix of its own. Either way, it's kept by every logger derived from this one.
+func WithService(name string) Option {
+	return func(l *Logger) {
+		l.service = []byte(name)
+	}
+}
+
+// WithJSON makes the logger write each log line as a compact JSON object on
+// a single line (NDJSON), suitable for consumption by other programs.
+//
+// The reserved keys "time", "level", "service" (if set), "prefix" (if set) and "msg"
+// always come first and in that order, followed by any attrs. No colour is applied to JSON output.
+func WithJSON() Option {
+	return func(l *Logger) {
+		l.json = true
+	}
+}
+
+// WithJSONPretty is like [WithJSON] but each record is indented over multiple
+// lines for human readability, useful when eyeballing structured logs during
+// development.
+//
+// Pretty records are still valid JSON but are no longer one per line.
+func WithJSONPretty() Option {
+	return func(l *Logger) {
+		l.json = true
+		l.jsonPretty = true
+	}
+}
+
+// WithLineEnding sets the line ending written after each line, either "\n" (the
+// default) or "\r\n" for consumers that expect Windows line endings. Anything else
+// leaves the line ending unchanged.
+//
+// It applies to every line the logger writes itself, including lines wrapped by
+// [WithWrapWidth] and those written by [Logger.Dump], [Logger.Separator] etc., but not
+// to text passed to [Logger.Raw], newlines within messages or attr values, or the
+// inside of pretty printed JSON records.
+func WithLineEnding(ending string) Option {
+	return func(l *Logger) {
+		switch ending {
+		case lf:
+			l.crlf = false
+		case crlf:
+			l.crlf = true
+		}
+	}
+}
+
+// WithLineHook sets a hook that is called with the level and fully rendered bytes
+// of every log line, just before it is written.
+//
+// This is useful for streaming logs elsewhere, e.g. into a TUI widget or over a websocket.
+//
+// The hook is called while the logger's lock is held so lines are seen in the same
+// order in which they are written, but it also means a slow hook will hold up logging
+// and the hook must not log through the same logger (or any logger derived from it)
+// or it will deadlock. The line is only valid for the duration of the call as its
+// memory is reused, a hook that needs to keep it must copy it.
+func WithLineHook(hook func(level Level, line []byte)) Option {
+	return func(l *Logger) {
+		l.lineHook = hook
+	}
+}
+
+// WithWrapWidth wraps text log lines that would be wider than cols terminal columns,
+// breaking between attrs onto continuation lines indented under the message. A single
+// key=value pair is never split over lines.
+//
+// If cols is 0, the width of the terminal the logger writes to is used, if the
+// logger isn't writing to a terminal, lines are not wrapped.
+//
+// JSON output is never wrapped.
+func WithWrapWidth(cols int) Option {
+	return func(l *Logger) {
+		l.autoWrap = cols == 0
+		if l.autoWrap {
+			cols = terminalWidth(l.w)
+		}
+
+		l.wrapWidth = max(cols, 0)
+	}
+}
+
+// WithDynamicAttrs sets a function that is called for every log line the logger writes,
+// returning attrs to add to it after the per-call attrs. Unlike [Logger.With], whose attrs
+// are fixed, this is recomputed for each line so it suits context that changes over time:
+//
+//	logger := log.New(os.Stderr, log.WithDynamicAttrs(func() []slog.Attr {
+//		return []slog.Attr{slog.Int("inflight", int(inflight.Load()))}
+//	}))
+//
+// fn is only called once a line has passed the level check, so it costs nothing on
+// filtered lines, but it is called on every line that is written, from whichever goroutine
+// is logging, and so must be fast and safe for concurrent use. Any allocations it makes
+// (such as the returned slice) are paid on every log call.
+func WithDynamicAttrs(fn func() []slog.Attr) Option {
+	return func(l *Logger) {
+		l.dynamicAttrs = fn
+	}
+}
+
+// WithAttrLayout sets the order in which attrs are rendered on each log line.
+//
+// See [AttrLayout] for the available presets, the default is [InsertionOrder] where
+// persistent attrs come first followed by per-call attrs.
+func WithAttrLayout(layout AttrLayout) Option {
+	return func(l *Logger) {
+		l.attrLayout = layout
+	}
+}
+
+// WithDurationThresholds colours duration attr values by how long they are, making slow
+// operations stand out: durations shorter than fast are green, those shorter than slow
+// are yellow and anything else is red.
+//
+// Sensible values for most command line tools are 100ms and 1s:
+//
+//	logger := log.New(os.Stderr, log.WithDurationThresholds(100*time.Millisecond, time.Second))
+//
+// Like all other styling, the colours are only shown if colour is enabled. If slow is
+// not greater than 0, durations are not coloured.
+func WithDurationThresholds(fast, slow time.Duration) Option {
+	return func(l *Logger) {
+		l.fastDuration = fast
+		l.slowDuration = slow
+	}
+}
+
+// WithTerminalClearLine makes the logger clear the current terminal line before
+// writing each log line, by writing a carriage return and the ANSI erase line sequence.
+//
+// This means logs are shown cleanly above a progress spinner or other live output on the
+// same line, rather than being mangled into it. It only has any effect if the logger is
+// writing to a terminal.
+func WithTerminalClearLine() Option {
+	return func(l *Logger) {
+		l.autoClearLine = true
+		l.clearLine = isTerminal(l.w)
+	}
+}
+
+// WithListFormat sets how slice and array attr values are rendered in text logs.
+//
+// The default is [ListGoSyntax] e.g. choices=[a b c], whereas [ListComma] gives
+// choices=a,b,c which often reads better on the command line. Values are quoted if
+// the rendered list contains whitespace.
+func WithListFormat(format ListFormat) Option {
+	return func(l *Logger) {
+		l.listFormat = format
+	}
+}
+
+// WithBufferHint sets the capacity, in bytes, that the buffers used to build each log
+// line are grown to before use.
+//
+// Line buffers are pooled and start small, growing as needed. Loggers that typically
+// write long lines (e.g. with lots of attrs) can set this to around their typical
+// line length so fresh buffers don't have to be reallocated as they grow. Hints above
+// 64KiB are capped. Most programs will not need this.
+func WithBufferHint(bytes int) Option {
+	return func(l *Logger) {
+		l.bufferHint = bytes
+	}
+}
+
+// WithBufferStrategy sets how the buffers log lines are rendered into are recycled.
+//
+// The default, [PoolUnbounded], keeps them in a [sync.Pool] which is fastest but may
+// hold on to a lot of memory after a burst of logging from many goroutines. [FreeList]
+// instead keeps at most a fixed number of buffers, capping the memory retained by the
+// logger at the cost of allocating when more lines than that are being written at once:
+//
+//	logger := log.New(os.Stderr, log.WithBufferStrategy(log.FreeList(16)))
+//
+// The buffers are shared by the logger and every logger derived from it.
+func WithBufferStrategy(strategy BufferStrategy) Option {
+	return func(l *Logger) {
+		if strategy <= PoolUnbounded {
+			l.freeList = nil
+			return
+		}
+
+		l.freeList = make(chan *[]byte, strategy)
+	}
+}
+
+// WithProcessInfo adds the process ID and, if it can be determined, the hostname to
+// the persistent attrs of the logger as pid and hostname, as is conventional for the
+// logs of long running services.
+//
+// Both are looked up once, when the logger is created. If the hostname can't be
+// determined, it's left out.
+func WithProcessInfo() Option {
+	return func(l *Logger) {
+		l.attrs = append(l.attrs, slog.Int(pidKey, os.Getpid()))
+
+		if hostname, err := os.Hostname(); err == nil && hostname != "" {
+			l.attrs = append(l.attrs, slog.String(hostnameKey, hostname))
+		}
+	}
+}
+
+// WithCapture makes the logger keep everything it would write in memory, retrievable
+// with [Logger.Captured], rather than writing it. Nothing is written to the logger's
+// writer, which may be [io.Discard].
+//
+// This is useful for showing what would have been logged, e.g. for a --dry-run flag.
+// Loggers derived from the logger (with [Logger.With] etc.) share its captured output.
+// Every line is kept until the program exits, so this is not suitable for long running
+// programs that log a lot.
+func WithCapture() Option {
+	return func(l *Logger) {
+		l.captured = &[]string{}
+		l.isDiscard = false
+	}
+}
+
+// WithErrorHandler sets a handler that is called with the error whenever writing
+// a log line fails, such as when writing to a closed file or broken network connection.
+//
+// By default, just like printing with [fmt.Println], write errors are silently ignored.
+//
+// The handler is called while the logger's lock is held so it must not log through
+// the same logger (or any logger derived from it), doing so will deadlock. It may
+// log through an entirely separate logger (e.g. one writing to [os.Stderr]).
+func WithErrorHandler(handler func(err error)) Option {
+	return func(l *Logger) {
+		l.errorHandler = handler
+	}
+}
+
+// WithColor sets whether the logger colourises its output.
+//
+// The default, [ColorAuto], defers to hue's global colour detection. [ColorAlways] and
+// [ColorNever] apply to this logger (and any derived from it) only, regardless of
+// hue's global state. This makes it possible to e.g. capture coloured output in a
+// [bytes.Buffer] for a snapshot test while the rest of the program is uncoloured.
+// [ColorDetect] colourises only if the logger's own writer is a terminal.
+func WithColor(mode ColorMode) Option {
+	return func(l *Logger) {
+		l.SetColor(mode)
+	}
+}
+
+// WithSequenceNumbers adds a sequence number to the end of every line as seq=<n>,
+// counting up from 1 in the order the log calls were made. The counter is shared with
+// every logger derived from this one (see [Logger.With], [Logger.Prefixed] etc.), so the
+// original order of lines from concurrent code can be reconstructed by sorting on it,
+// even when the lines are interleaved differently in the output.
+//
+// Lines filtered out by level don't use up a number.
+func WithSequenceNumbers() Option {
+	return func(l *Logger) {
+		l.seq = &atomic.Uint64{}
+	}
+}
+
+// WithShadowWarnings makes per-call attrs win over persistent attrs (see [Logger.With])
+// with the same key, so only the per-call one is shown, and counts each time this
+// happens. The count is returned by [Logger.Shadowed], e.g. to catch persistent attrs
+// being overwritten by accident in tests:
+//
+//	logger := log.New(os.Stderr, log.WithShadowWarnings()).With(slog.String("user", "a"))
+//	logger.Info("Hello", slog.String("user", "b")) // ... INFO:  Hello user=b
+//	logger.Shadowed() // 1
+//
+// Without it, both are shown.
+func WithShadowWarnings() Option {
+	return func(l *Logger) {
+		l.shadowed = &atomic.Uint64{}
+	}
+}
+
+// WithCaller adds the location of the log call to the end of every line as
+// source=<file>:<line>, e.g. source=main.go:42, to help find where a line came from.
+// See [WithCallerKey] to use a key other than "source".
+//
+// Finding the caller means walking the stack on every log call, which is relatively
+// expensive, so this is best left for debugging.
+func WithCaller() Option {
+	return func(l *Logger) {
+		l.caller = true
+	}
+}
+
+// WithCallerKey sets the key of the caller attr added by [WithCaller], for consistency
+// with other logging conventions, e.g. "caller" or "@caller". The default is "source".
+//
+// An empty key is ignored. On its own this doesn't add the caller to log lines,
+// [WithCaller] must be passed too.
+func WithCallerKey(key string) Option {
+	return func(l *Logger) {
+		if key != "" {
+			l.callerKey = key
+		}
+	}
+}
+
+// WithGoroutineID adds the ID of the goroutine making the log call to the end of every
+// line as goroutine=<id>, which can help untangle logs from concurrent code.
+//
+// Getting the ID involves formatting the goroutine's stack trace so it's relatively
+// expensive, this is intended for debugging only. Goroutine IDs are also reused once a
+// goroutine exits, so it is not a stable identity.
+func WithGoroutineID() Option {
+	return func(l *Logger) {
+		l.goroutineID = true
+	}
+}
+
+// WithUnbuffered makes the logger write each component of a text log line (timestamp,
+// level, prefix, message, each attr etc.) to its writer with a separate call to Write,
+// rather than assembling the whole line in a pooled buffer and writing it in one go.
+//
+// This is slower and intended only for debugging the behaviour of a writer. The logger's
+// lock is held for the whole line so lines are never interleaved with one another.
+// JSON records are always written in one go.
+func WithUnbuffered() Option {
+	return func(l *Logger) {
+		l.unbuffered = true
+	}
+}
@@ -0,0 +1,64 @@
+package log
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// pathPrefixes are the prefixes of values treated as paths by [WithSmartPathQuoting].
+//
+//nolint:gochecknoglobals // Constant lookup table
+var pathPrefixes = []string{"/", "./", "../", "~/"}
+
+// isPathLike reports whether s looks like a file path or URL, see [WithSmartPathQuoting].
+func isPathLike(s string) bool {
+	for _, prefix := range pathPrefixes {
+		if strings.HasPrefix(s, prefix) {
+			return true
+		}
+	}
+
+	scheme, _, ok := strings.Cut(s, "://")
+	if !ok || scheme == "" {
+		return false
+	}
+
+	// Per RFC 3986, a letter followed by letters, digits, "+", "-" or "."
+	for i := range len(scheme) {
+		switch char := scheme[i]; {
+		case 'a' <= char && char <= 'z', 'A' <= char && char <= 'Z':
+		case i != 0 && ('0' <= char && char <= '9' || char == '+' || char == '-' || char == '.'):
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// appendPercentEncoded appends s to dst with every byte of the characters that would
+// otherwise need it quoted (see needsQuotes) percent encoded, and returns the extended
+// slice, e.g. "/my file.txt" becomes "/my%20file.txt".
+//
+// A literal '%' is encoded too, as "%25", so the result always decodes back to s.
+func appendPercentEncoded(dst []byte, s string) []byte {
+	const hex = "0123456789ABCDEF"
+
+	for i := 0; i < len(s); {
+		char, size := utf8.DecodeRuneInString(s[i:])
+
+		if char != utf8.RuneError && char != '%' && char > ' ' && char != 0x7f && !unicode.IsSpace(char) && unicode.IsPrint(char) {
+			dst = append(dst, s[i:i+size]...)
+			i += size
+
+			continue
+		}
+
+		for end := i + size; i < end; i++ {
+			dst = append(dst, '%', hex[s[i]>>4], hex[s[i]&0x0f])
+		}
+	}
+
+	return dst
+}
@@ -0,0 +1,54 @@
+package log
+
+import (
+	"strconv"
+	"unicode"
+	"unicode/utf8"
+)
+
+// validQuote reports whether r is usable as a quote character, see [WithQuoteChar].
+func validQuote(r rune) bool {
+	return r != utf8.RuneError && r != '\\' && unicode.IsPrint(r) && !unicode.IsSpace(r) &&
+		!unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+// appendQuoted appends s to dst wrapped in the logger's quote character and returns
+// the extended slice.
+//
+// The default double quote uses Go's escaping, as [strconv.Quote] does. Any other quote
+// character only escapes itself and backslashes with a backslash, with non printable
+// runes and invalid UTF-8 escaped as Go would.
+func (l *Logger) appendQuoted(dst []byte, s string) []byte {
+	if l.quote == 0 || l.quote == '"' {
+		return strconv.AppendQuote(dst, s)
+	}
+
+	dst = utf8.AppendRune(dst, l.quote)
+
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+
+		switch {
+		case r == utf8.RuneError && size == 1:
+			// Invalid UTF-8, escape the raw byte as Go would rather than replacing it
+			const hex = "0123456789abcdef"
+
+			dst = append(dst, '\\', 'x', hex[s[i]>>4], hex[s[i]&0xf])
+		case r == l.quote || r == '\\':
+			dst = append(dst, '\\')
+			dst = utf8.AppendRune(dst, r)
+		case r == ' ' || unicode.IsPrint(r):
+			dst = utf8.AppendRune(dst, r)
+		default:
+			// Borrow Go's escape for the rune, without the quotes around it
+			var scratch [scratchSize]byte
+
+			escaped := strconv.AppendQuoteRuneToASCII(scratch[:0], r)
+			dst = append(dst, escaped[1:len(escaped)-1]...)
+		}
+
+		i += size
+	}
+
+	return utf8.AppendRune(dst, l.quote)
+}
@@ -0,0 +1,113 @@
+package log
+
+import (
+	"io"
+	"sync"
+	"sync/atomic"
+)
+
+// tailBuffer is the number of lines buffered for each reader returned by [Logger.Tail],
+// any more written while it's full are dropped for that reader.
+const tailBuffer = 256
+
+// tailHub broadcasts everything written by a logger, and those derived from it, to
+// the readers returned by [Logger.Tail].
+type tailHub struct {
+	subscribers map[*tailReader]struct{} // The readers currently subscribed, protected by mu
+	mu          sync.Mutex               // Protects subscribers
+	n           atomic.Int32             // len(subscribers), so publishing can skip the lock when there are none
+}
+
+// publish sends a copy of p to every subscribed reader that has room for it.
+func (h *tailHub) publish(p []byte) {
+	if h.n.Load() == 0 {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for reader := range h.subscribers {
+		select {
+		case reader.lines <- append([]byte(nil), p...):
+		default:
+			// The reader isn't keeping up, drop the line rather than block logging
+		}
+	}
+}
+
+// tailReader is an [io.Reader] of the lines written by a logger, see [Logger.Tail].
+type tailReader struct {
+	lines   chan []byte   // Lines waiting to be read
+	done    chan struct{} // Closed when the reader is cancelled
+	pending []byte        // The unread remainder of the last line received
+}
+
+// Read implements [io.Reader], blocking until a line is available. Once the reader is
+// cancelled and any lines already received are read it returns [io.EOF].
+func (r *tailReader) Read(p []byte) (int, error) {
+	if len(r.pending) == 0 {
+		select {
+		case r.pending = <-r.lines:
+		case <-r.done:
+			// Drain anything received before cancelling
+			select {
+			case r.pending = <-r.lines:
+			default:
+				return 0, io.EOF
+			}
+		}
+	}
+
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+
+	return n, nil
+}
+
+// Tail returns a reader that yields everything written by the logger, and every logger
+// sharing its lineage, from now on, e.g. to show live logs in a pane of a terminal UI
+// while they're still written as normal. The returned function unsubscribes the reader,
+// after which it returns [io.EOF] once any lines already received have been read.
+//
+//	reader, cancel := logger.Tail()
+//	defer cancel()
+//
+//	scanner := bufio.NewScanner(reader)
+//	for scanner.Scan() {
+//		pane.Append(scanner.Text())
+//	}
+//
+// Any number of readers may be subscribed at once, each receiving every line. A slow
+// reader never holds up logging: up to 256 lines are buffered for each reader, anything
+// written while its buffer is full is dropped for that reader only. Lines are as written,
+// so include colour if the logger uses it. Loggers discarding their output write nothing
+// to tail.
+func (l *Logger) Tail() (io.Reader, func()) {
+	reader := &tailReader{
+		lines: make(chan []byte, tailBuffer),
+		done:  make(chan struct{}),
+	}
+
+	hub := l.tails
+
+	hub.mu.Lock()
+	if hub.subscribers == nil {
+		hub.subscribers = make(map[*tailReader]struct{})
+	}
+
+	hub.subscribers[reader] = struct{}{}
+	hub.n.Add(1)
+	hub.mu.Unlock()
+
+	cancel := sync.OnceFunc(func() {
+		hub.mu.Lock()
+		defer hub.mu.Unlock()
+
+		delete(hub.subscribers, reader)
+		hub.n.Add(-1)
+		close(reader.done)
+	})
+
+	return reader, cancel
+}
@@ -0,0 +1,71 @@
+package log_test
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"testing"
+
+	"go.followtheprocess.codes/log"
+	"go.followtheprocess.codes/test"
+)
+
+func TestTail(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := log.New(buf, log.WithPlain())
+
+	logger.Info("Before subscribing")
+
+	first, cancelFirst := logger.Tail()
+	second, cancelSecond := logger.Tail()
+
+	logger.Info("One")
+	logger.Prefixed("child").Warn("Two")
+
+	cancelFirst()
+	cancelFirst() // Must be safe to call again
+
+	logger.Info("Three")
+	cancelSecond()
+
+	test.Diff(t, readAll(t, first), "INFO:  One\nWARN child:  Two\n")
+	test.Diff(t, readAll(t, second), "INFO:  One\nWARN child:  Two\nINFO:  Three\n")
+
+	// The main writer is unaffected
+	test.Diff(t, buf.String(), "INFO:  Before subscribing\nINFO:  One\nWARN child:  Two\nINFO:  Three\n")
+}
+
+func TestTailSlowReader(t *testing.T) {
+	logger := log.New(io.Discard, log.WithCapture(), log.WithPlain())
+
+	reader, cancel := logger.Tail()
+
+	// Nobody is reading, logging must not block
+	for range 1000 {
+		logger.Info("Spam")
+	}
+
+	cancel()
+
+	scanner := bufio.NewScanner(reader)
+
+	lines := 0
+	for scanner.Scan() {
+		test.Equal(t, scanner.Text(), "INFO:  Spam")
+		lines++
+	}
+
+	test.Ok(t, scanner.Err())
+	test.Equal(t, lines, 256, test.Context("lines beyond the buffer should be dropped"))
+	test.Equal(t, len(logger.Captured()), 1000)
+}
+
+// readAll reads everything from r, which must end with [io.EOF].
+func readAll(t *testing.T, r io.Reader) string {
+	t.Helper()
+
+	got, err := io.ReadAll(r)
+	test.Ok(t, err)
+
+	return string(got)
+}
@@ -0,0 +1,67 @@
+package log
+
+import "io"
+
+// Destination is a writer for [NewTee], along with how to format log lines for it.
+type Destination struct {
+	W       io.Writer // Where to write log lines
+	Options []Option  // Options controlling how lines are formatted for W, as passed to [New]
+}
+
+// NewTee returns a new [Logger] that writes every log line to each of destinations,
+// formatted independently for each one. This allows e.g. rich, wrapped text on a
+// terminal alongside strict plain text or JSON in a file:
+//
+//	logger := log.NewTee(
+//		[]log.Destination{
+//			{W: os.Stderr, Options: []log.Option{log.WithColor(log.ColorDetect), log.WithWrapWidth(100)}},
+//			{W: file, Options: []log.Option{log.WithPlain(), log.WithTimePreset(log.PresetRFC3339Millis)}},
+//		},
+//		log.WithLevel(log.LevelDebug),
+//	)
+//
+// options configure what is logged, as with [New]: the level, persistent attrs, the clock
+// and so on. Each destination's options only control how lines are formatted for it,
+// although a [WithLevel] there filters out lines below that level for that destination
+// alone, as do any other level options there e.g. [WithPrefixLevel]. Each destination is
+// protected by its own lock, and [Logger.Tail] receives the lines written to every one.
+//
+// Only log lines are written to the destinations, output from [Logger.Raw],
+// [Logger.Separator], [Logger.Banner], [Logger.Dump] and [Logger.Errors] is discarded.
+func NewTee(destinations []Destination, options ...Option) *Logger {
+	logger := New(io.Discard, options...)
+	logger.isDiscard = false
+
+	logger.sinks = make([]*Logger, 0, len(destinations))
+	for _, destination := range destinations {
+		// Destinations show everything the logger does unless they say otherwise
+		sinkOptions := append([]Option{WithLevel(logger.level)}, destination.Options...)
+		logger.sinks = append(logger.sinks, New(destination.W, sinkOptions...))
+	}
+
+	return logger
+}
+
+// writeSinks renders rec for each of the logger's destinations and writes it, see [NewTee].
+func (l *Logger) writeSinks(rec *record) {
+	for _, sink := range l.sinks {
+		// Render as the sink would, but with the prefix of the logger that made the line
+		// and publishing to its readers from Tail
+		renderer := *sink
+		renderer.prefix = l.prefix
+		renderer.tails = l.tails
+
+		// The sink's own level rules e.g. WithPrefixLevel apply to the line's prefix
+		if renderer.isDiscard || !renderer.enabled(rec.level) {
+			continue
+		}
+
+		bufp := getBuffer(renderer.freeList, renderer.bufferHint)
+
+		buf := renderer.render(*bufp, rec)
+		*bufp = buf
+
+		renderer.emit(rec.level, buf)
+		putBuffer(renderer.freeList, bufp)
+	}
+}
@@ -0,0 +1,73 @@
+package log_test
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+	"time"
+
+	"go.followtheprocess.codes/hue"
+	"go.followtheprocess.codes/log"
+	"go.followtheprocess.codes/test"
+)
+
+func TestTee(t *testing.T) {
+	hue.Enabled(false) // Force no color
+
+	fixedTime := func() time.Time {
+		return time.Date(2025, time.April, 1, 13, 34, 3, 0, time.UTC)
+	}
+
+	terminal := &bytes.Buffer{}
+	file := &bytes.Buffer{}
+	errorsOnly := &bytes.Buffer{}
+
+	logger := log.NewTee(
+		[]log.Destination{
+			{W: terminal, Options: []log.Option{log.WithColor(log.ColorAlways), log.TimeFormat(time.TimeOnly)}},
+			{W: file, Options: []log.Option{log.WithJSON()}},
+			{W: errorsOnly, Options: []log.Option{log.WithPlain(), log.WithLevel(log.LevelError)}},
+		},
+		log.TimeFunc(fixedTime),
+		log.WithLevel(log.LevelDebug),
+	)
+
+	logger.Debug("Starting")
+	logger.Prefixed("db").With(slog.String("host", "localhost")).Error("Connection refused")
+
+	wantTerminal := "\x1b[2m13:34:03\x1b[0m \x1b[1;34mDEBUG\x1b[0m: Starting\n" +
+		"\x1b[2m13:34:03\x1b[0m \x1b[1;31mERROR\x1b[0m \x1b[1;2mdb\x1b[0m: Connection refused \x1b[35mhost\x1b[0m=localhost\n"
+
+	wantFile := `{"time":"2025-04-01T13:34:03Z","level":"DEBUG","msg":"Starting"}` + "\n" +
+		`{"time":"2025-04-01T13:34:03Z","level":"ERROR","prefix":"db","msg":"Connection refused","host":"localhost"}` + "\n"
+
+	test.Diff(t, terminal.String(), wantTerminal)
+	test.Diff(t, file.String(), wantFile)
+	test.Diff(t, errorsOnly.String(), "ERROR db: Connection refused host=localhost\n")
+}
+
+func TestTeeLevelRules(t *testing.T) {
+	hue.Enabled(false) // Force no color
+
+	terminal := &bytes.Buffer{}
+	file := &bytes.Buffer{}
+
+	logger := log.NewTee(
+		[]log.Destination{
+			{W: terminal, Options: []log.Option{log.WithPlain(), log.WithPrefixLevel(map[string]log.Level{"noisy": log.LevelWarn})}},
+			{W: file, Options: []log.Option{log.WithPlain()}},
+		},
+		log.WithLevel(log.LevelDebug),
+	)
+
+	reader, cancel := logger.Tail()
+
+	logger.Prefixed("noisy").Info("Chatter")
+	logger.Prefixed("noisy").Warn("Important")
+
+	cancel()
+
+	test.Diff(t, terminal.String(), "WARN noisy:  Important\n")
+	test.Diff(t, file.String(), "INFO noisy:  Chatter\nWARN noisy:  Important\n")
+	test.Diff(t, readAll(t, reader), "INFO noisy:  Chatter\nWARN noisy:  Important\nWARN noisy:  Important\n")
+}
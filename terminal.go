@@ -0,0 +1,43 @@
+package log
+
+import (
+	"io"
+
+	"golang.org/x/term"
+)
+
+// fder is a writer backed by a file descriptor, such as an [os.File].
+type fder interface {
+	Fd() uintptr
+}
+
+// isTerminal reports whether w is a terminal.
+//
+// It's a variable so tests can pretend to be writing to a terminal.
+//
+//nolint:gochecknoglobals // Swapped out in tests only
+var isTerminal = func(w io.Writer) bool {
+	f, ok := w.(fder)
+
+	return ok && term.IsTerminal(int(f.Fd())) //nolint:gosec // File descriptors always fit in an int
+}
+
+// terminalWidth returns the width in columns of the terminal w writes to, or
+// 0 if w is not a terminal or its size can't be determined.
+//
+// Like isTerminal, it's a variable so tests can pretend to know the width.
+//
+//nolint:gochecknoglobals // Swapped out in tests only
+var terminalWidth = func(w io.Writer) int {
+	f, ok := w.(fder)
+	if !ok {
+		return 0
+	}
+
+	width, _, err := term.GetSize(int(f.Fd())) //nolint:gosec // File descriptors always fit in an int
+	if err != nil {
+		return 0
+	}
+
+	return width
+}
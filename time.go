@@ -0,0 +1,113 @@
+package log
+
+import (
+	"strconv"
+	"time"
+)
+
+const (
+	// rfc3339Millis is RFC3339 with the fractional seconds fixed at milliseconds.
+	rfc3339Millis = "2006-01-02T15:04:05.000Z07:00"
+
+	// timeOfDay is the time of day part of RFC3339, following the date in
+	// [PresetISOWeek] and [PresetOrdinal] timestamps.
+	timeOfDay = "T15:04:05Z07:00"
+)
+
+// TimePreset is a named timestamp format, see [WithTimePreset].
+type TimePreset int
+
+const (
+	// PresetRFC3339 is [time.RFC3339] e.g. 2025-04-01T13:34:03Z. This is the default.
+	PresetRFC3339 TimePreset = iota
+
+	// PresetRFC3339Millis is RFC3339 with millisecond precision e.g. 2025-04-01T13:34:03.123Z.
+	PresetRFC3339Millis
+
+	// PresetKitchen is [time.Kitchen] e.g. 1:34PM.
+	PresetKitchen
+
+	// PresetDateTime is [time.DateTime] e.g. 2025-04-01 13:34:03.
+	PresetDateTime
+
+	// PresetTimeOnly is [time.TimeOnly] e.g. 13:34:03.
+	PresetTimeOnly
+
+	// PresetUnix is the number of seconds since the Unix epoch as an integer e.g. 1743514443.
+	// In JSON output it's written as a number rather than a string.
+	PresetUnix
+
+	// PresetISOWeek is an ISO 8601 week date and time e.g. 2025-W14-2T13:34:03Z, the
+	// ISO week based year, the week number and the day of the week from Monday (1)
+	// to Sunday (7).
+	PresetISOWeek
+
+	// PresetOrdinal is an ISO 8601 ordinal date and time e.g. 2025-091T13:34:03Z, the
+	// year followed by the day of the year.
+	PresetOrdinal
+)
+
+// appendTimestamp appends the formatted form of t to dst and returns the extended slice.
+func (l *Logger) appendTimestamp(dst []byte, t time.Time) []byte {
+	switch l.timePreset {
+	case PresetUnix:
+		return strconv.AppendInt(dst, t.Unix(), base10)
+	case PresetISOWeek:
+		return appendISOWeek(dst, t)
+	case PresetOrdinal:
+		return appendOrdinal(dst, t)
+	default:
+		return t.AppendFormat(dst, l.timeFormat)
+	}
+}
+
+// appendElapsed appends the time elapsed between the logger's creation and t to dst,
+// to the millisecond, and returns the extended slice, see [WithHybridTime].
+func (l *Logger) appendElapsed(dst []byte, t time.Time) []byte {
+	dst = append(dst, '+')
+
+	return append(dst, t.Sub(l.start).Truncate(time.Millisecond).String()...)
+}
+
+// appendISOWeek appends t as an ISO 8601 week date and time to dst and returns the
+// extended slice, see [PresetISOWeek].
+func appendISOWeek(dst []byte, t time.Time) []byte {
+	year, week := t.ISOWeek()
+
+	// time.Weekday counts from Sunday (0), ISO from Monday (1)
+	day := int(t.Weekday())
+	if day == 0 {
+		day = 7
+	}
+
+	dst = appendPadded(dst, year, 4)
+	dst = append(dst, '-', 'W')
+	dst = appendPadded(dst, week, 2)
+	dst = append(dst, '-')
+	dst = appendPadded(dst, day, 1)
+
+	return t.AppendFormat(dst, timeOfDay)
+}
+
+// appendOrdinal appends t as an ISO 8601 ordinal date and time to dst and returns the
+// extended slice, see [PresetOrdinal].
+func appendOrdinal(dst []byte, t time.Time) []byte {
+	dst = appendPadded(dst, t.Year(), 4)
+	dst = append(dst, '-')
+	dst = appendPadded(dst, t.YearDay(), 3)
+
+	return t.AppendFormat(dst, timeOfDay)
+}
+
+// appendPadded appends n to dst, zero padded to at least width digits, and returns
+// the extended slice.
+func appendPadded(dst []byte, n, width int) []byte {
+	var scratch [scratchSize]byte
+
+	digits := strconv.AppendInt(scratch[:0], int64(n), base10)
+	for range width - len(digits) {
+		dst = append(dst, '0')
+	}
+
+	return append(dst, digits...)
+}
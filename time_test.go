@@ -0,0 +1,123 @@
+package log_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"go.followtheprocess.codes/hue"
+	"go.followtheprocess.codes/log"
+	"go.followtheprocess.codes/test"
+)
+
+func TestTimePreset(t *testing.T) {
+	hue.Enabled(false) // Force no color
+
+	fixedTime := func() time.Time {
+		return time.Date(2025, time.April, 1, 13, 34, 3, 123456789, time.UTC)
+	}
+
+	tests := []struct {
+		name    string       // Name of the test case
+		want    string       // Expected log line
+		options []log.Option // Options to configure the logger
+	}{
+		{
+			name:    "rfc3339",
+			options: []log.Option{log.WithTimePreset(log.PresetRFC3339)},
+			want:    "2025-04-01T13:34:03Z INFO:  Hello\n",
+		},
+		{
+			name:    "rfc3339 millis",
+			options: []log.Option{log.WithTimePreset(log.PresetRFC3339Millis)},
+			want:    "2025-04-01T13:34:03.123Z INFO:  Hello\n",
+		},
+		{
+			name:    "kitchen",
+			options: []log.Option{log.WithTimePreset(log.PresetKitchen)},
+			want:    "1:34PM INFO:  Hello\n",
+		},
+		{
+			name:    "date time",
+			options: []log.Option{log.WithTimePreset(log.PresetDateTime)},
+			want:    "2025-04-01 13:34:03 INFO:  Hello\n",
+		},
+		{
+			name:    "time only",
+			options: []log.Option{log.WithTimePreset(log.PresetTimeOnly)},
+			want:    "13:34:03 INFO:  Hello\n",
+		},
+		{
+			name:    "unix",
+			options: []log.Option{log.WithTimePreset(log.PresetUnix)},
+			want:    "1743514443 INFO:  Hello\n",
+		},
+		{
+			name:    "unix json",
+			options: []log.Option{log.WithTimePreset(log.PresetUnix), log.WithJSON()},
+			want:    `{"time":1743514443,"level":"INFO","msg":"Hello"}` + "\n",
+		},
+		{
+			name:    "kitchen json",
+			options: []log.Option{log.WithTimePreset(log.PresetKitchen), log.WithJSON()},
+			want:    `{"time":"1:34PM","level":"INFO","msg":"Hello"}` + "\n",
+		},
+		{
+			name:    "iso week",
+			options: []log.Option{log.WithTimePreset(log.PresetISOWeek)},
+			want:    "2025-W14-2T13:34:03Z INFO:  Hello\n",
+		},
+		{
+			name:    "ordinal",
+			options: []log.Option{log.WithTimePreset(log.PresetOrdinal)},
+			want:    "2025-091T13:34:03Z INFO:  Hello\n",
+		},
+		{
+			name:    "time format after preset wins",
+			options: []log.Option{log.WithTimePreset(log.PresetUnix), log.TimeFormat(time.Kitchen)},
+			want:    "1:34PM INFO:  Hello\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+
+			logger := log.New(buf, append(tt.options, log.TimeFunc(fixedTime))...)
+
+			logger.Info("Hello")
+
+			test.Diff(t, buf.String(), tt.want)
+		})
+	}
+}
+
+func TestHybridTime(t *testing.T) {
+	hue.Enabled(false) // Force no color
+
+	now := time.Date(2025, time.April, 1, 13, 34, 3, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	buf := &bytes.Buffer{}
+	logger := log.New(buf, log.TimeFunc(clock), log.WithHybridTime(), log.WithLevel(log.LevelDebug))
+
+	logger.Debug("Starting")
+
+	now = now.Add(1500 * time.Millisecond)
+	logger.Prefixed("build").Info("Building")
+
+	now = now.Add(time.Minute)
+	logger.Error("Build failed")
+	logger.Warn("Retrying")
+
+	now = now.Add(time.Hour)
+	logger.Info("Done")
+
+	want := "+0s DEBUG: Starting\n" +
+		"+1.5s INFO build:  Building\n" +
+		"2025-04-01T13:35:04Z ERROR: Build failed\n" +
+		"2025-04-01T13:35:04Z WARN:  Retrying\n" +
+		"+1h1m1.5s INFO:  Done\n"
+
+	test.Diff(t, buf.String(), want)
+}
@@ -0,0 +1,76 @@
+package log
+
+import (
+	"log/slog"
+	"sync/atomic"
+	"time"
+)
+
+// TimerOption configures how [Logger.Timer] and [Logger.Track] log a timed operation.
+type TimerOption func(*timerConfig)
+
+// timerConfig is the configuration of a timed operation, set by TimerOptions.
+type timerConfig struct {
+	warnAbove time.Duration // Operations taking at least this long log at warn, faster ones at debug, 0 means always info
+}
+
+// WarnAbove makes a timed operation (see [Logger.Timer] and [Logger.Track]) that takes
+// threshold or longer log at warn level, and one that's faster log at debug level, rather
+// than always logging at info. This surfaces slow operations while keeping fast ones out
+// of the way:
+//
+//	defer logger.Timer("Querying", log.WarnAbove(500*time.Millisecond))()
+//
+// A threshold that isn't positive is ignored.
+func WarnAbove(threshold time.Duration) TimerOption {
+	return func(cfg *timerConfig) {
+		cfg.warnAbove = threshold
+	}
+}
+
+// Timer starts timing an operation and returns a function that, when called, logs msg
+// with a "duration" attr of how long it's been since Timer was called. This makes timing
+// a whole function a one liner:
+//
+//	func bake() {
+//		defer logger.Timer("Baking")()
+//		// ...
+//	}
+//
+// The line is logged at info level unless configured otherwise with options, e.g.
+// [WarnAbove]. The returned function is safe to call more than once, only the first
+// call logs.
+func (l *Logger) Timer(msg string, options ...TimerOption) (stop func()) {
+	var cfg timerConfig
+	for _, option := range options {
+		option(&cfg)
+	}
+
+	start := l.timeFunc()
+
+	// Not sync.OnceFunc as its frames would sit between the caller and the logger,
+	// so WithCaller would report them rather than the code calling stop
+	var stopped atomic.Bool
+
+	return func() {
+		if !stopped.CompareAndSwap(false, true) {
+			return
+		}
+
+		elapsed := l.timeFunc().Sub(start)
+		l.log(cfg.level(elapsed), msg, slog.Duration(durationKey, elapsed))
+	}
+}
+
+// level returns the level a successful operation that took elapsed should be
+// logged at.
+func (cfg timerConfig) level(elapsed time.Duration) Level {
+	switch {
+	case cfg.warnAbove <= 0:
+		return LevelInfo
+	case elapsed >= cfg.warnAbove:
+		return LevelWarn
+	default:
+		return LevelDebug
+	}
+}
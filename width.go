@@ -0,0 +1,212 @@
+package log
+
+import (
+	"slices"
+	"unicode"
+	"unicode/utf8"
+)
+
+// runeRange is an inclusive range of runes.
+type runeRange struct {
+	lo, hi rune
+}
+
+// wideRunes are the ranges of runes that take up two terminal columns, the East Asian
+// Wide and Fullwidth characters and emoji presented as such by default, sorted so they
+// can be binary searched.
+//
+//nolint:gochecknoglobals // Constant lookup table
+var wideRunes = [...]runeRange{
+	{0x1100, 0x115F},   // Hangul Jamo initial consonants
+	{0x231A, 0x231B},   // Watch, hourglass
+	{0x2329, 0x232A},   // Angle brackets
+	{0x23E9, 0x23EC},   // Media controls
+	{0x23F0, 0x23F0},   // Alarm clock
+	{0x23F3, 0x23F3},   // Hourglass with flowing sand
+	{0x25FD, 0x25FE},   // Medium small squares
+	{0x2614, 0x2615},   // Umbrella, hot beverage
+	{0x2648, 0x2653},   // Zodiac
+	{0x267F, 0x267F},   // Wheelchair
+	{0x2693, 0x2693},   // Anchor
+	{0x26A1, 0x26A1},   // High voltage
+	{0x26AA, 0x26AB},   // Medium circles
+	{0x26BD, 0x26BE},   // Football, baseball
+	{0x26C4, 0x26C5},   // Snowman, sun behind cloud
+	{0x26CE, 0x26CE},   // Ophiuchus
+	{0x26D4, 0x26D4},   // No entry
+	{0x26EA, 0x26EA},   // Church
+	{0x26F2, 0x26F3},   // Fountain, golf
+	{0x26F5, 0x26F5},   // Sailboat
+	{0x26FA, 0x26FA},   // Tent
+	{0x26FD, 0x26FD},   // Fuel pump
+	{0x2705, 0x2705},   // Check mark button
+	{0x270A, 0x270B},   // Raised fist, hand
+	{0x2728, 0x2728},   // Sparkles
+	{0x274C, 0x274C},   // Cross mark
+	{0x274E, 0x274E},   // Cross mark button
+	{0x2753, 0x2755},   // Question and exclamation marks
+	{0x2757, 0x2757},   // Exclamation mark
+	{0x2795, 0x2797},   // Plus, minus, divide
+	{0x27B0, 0x27B0},   // Curly loop
+	{0x27BF, 0x27BF},   // Double curly loop
+	{0x2B1B, 0x2B1C},   // Large squares
+	{0x2B50, 0x2B50},   // Star
+	{0x2B55, 0x2B55},   // Hollow red circle
+	{0x2E80, 0x303E},   // CJK radicals, symbols and punctuation
+	{0x3041, 0x33FF},   // Hiragana, Katakana, Bopomofo, CJK compatibility etc.
+	{0x3400, 0x4DBF},   // CJK unified ideographs extension A
+	{0x4E00, 0x9FFF},   // CJK unified ideographs
+	{0xA000, 0xA4CF},   // Yi
+	{0xA960, 0xA97F},   // Hangul Jamo extended A
+	{0xAC00, 0xD7A3},   // Hangul syllables
+	{0xF900, 0xFAFF},   // CJK compatibility ideographs
+	{0xFE10, 0xFE19},   // Vertical forms
+	{0xFE30, 0xFE6F},   // CJK compatibility forms, small form variants
+	{0xFF00, 0xFF60},   // Fullwidth forms
+	{0xFFE0, 0xFFE6},   // Fullwidth signs
+	{0x16FE0, 0x16FE4}, // Ideographic symbols and punctuation
+	{0x17000, 0x18AFF}, // Tangut
+	{0x1B000, 0x1B2FF}, // Kana supplement and extended, Nushu
+	{0x1F004, 0x1F004}, // Mahjong tile red dragon
+	{0x1F0CF, 0x1F0CF}, // Joker
+	{0x1F18E, 0x1F18E}, // AB button
+	{0x1F191, 0x1F19A}, // Squared words
+	{0x1F200, 0x1F251}, // Enclosed ideographic supplement
+	{0x1F300, 0x1F64F}, // Miscellaneous symbols and pictographs, emoticons
+	{0x1F680, 0x1F6FF}, // Transport and map symbols
+	{0x1F7E0, 0x1F7EB}, // Coloured circles and squares
+	{0x1F90C, 0x1F9FF}, // Supplemental symbols and pictographs
+	{0x1FA70, 0x1FAFF}, // Symbols and pictographs extended A
+	{0x20000, 0x2FFFD}, // CJK unified ideographs extensions B-F
+	{0x30000, 0x3FFFD}, // CJK unified ideographs extension G onwards
+}
+
+// DisplayWidth returns the number of terminal columns s takes up when printed.
+//
+// ANSI escape sequences (such as those used for colour) take up no space, nor do
+// control characters, combining marks or other zero width characters like the zero
+// width joiner. East Asian wide characters (e.g. CJK) and most emoji take up two columns,
+// everything else takes up one.
+//
+// This is useful for laying out text alongside log lines, the logger uses it itself
+// for wrapping (see [WithWrapWidth]). It's a good approximation rather than exact:
+// terminals disagree on the width of some characters, and sequences of emoji joined
+// into one glyph are counted as the sum of their parts.
+func DisplayWidth(s string) int {
+	return displayWidth(s)
+}
+
+// displayWidth is [DisplayWidth] for either strings or bytes.
+func displayWidth[T []byte | string](text T) int {
+	width := 0
+
+	for i := 0; i < len(text); {
+		// Skip an entire escape sequence: ESC '[' params... final byte in the range 0x40-0x7e
+		if text[i] == '\x1b' && i+1 < len(text) && text[i+1] == '[' {
+			i += 2
+			for i < len(text) && (text[i] < 0x40 || text[i] > 0x7e) {
+				i++
+			}
+
+			i++ // The final byte
+
+			continue
+		}
+
+		// ASCII fast path, only control characters take up no space
+		if b := text[i]; b < utf8.RuneSelf {
+			if b >= ' ' && b != 0x7f {
+				width++
+			}
+
+			i++
+
+			continue
+		}
+
+		var (
+			r    rune
+			size int
+		)
+
+		switch text := any(text).(type) {
+		case string:
+			r, size = utf8.DecodeRuneInString(text[i:])
+		case []byte:
+			r, size = utf8.DecodeRune(text[i:])
+		}
+
+		width += runeWidth(r)
+		i += size
+	}
+
+	return width
+}
+
+// truncateWidth returns text shortened to at most width (which must be at least 1)
+// display columns, ending in an ellipsis if anything had to be cut off. text must not
+// contain escape sequences.
+func truncateWidth(text []byte, width int) []byte {
+	if displayWidth(text) <= width {
+		return text
+	}
+
+	// Leave room for the ellipsis
+	width--
+
+	used, end := 0, 0
+	for end < len(text) {
+		r, size := utf8.DecodeRune(text[end:])
+
+		columns := 1
+		if r >= utf8.RuneSelf {
+			columns = runeWidth(r)
+		}
+
+		if used+columns > width {
+			break
+		}
+
+		used += columns
+		end += size
+	}
+
+	return append(text[:end:end], "…"...)
+}
+
+// runeWidth returns the number of terminal columns taken up by a non ASCII rune.
+func runeWidth(r rune) int {
+	switch {
+	case r < 0xA0:
+		// C1 control characters
+		return 0
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf):
+		// Combining marks (including variation selectors) and format characters
+		// like the zero width joiner
+		return 0
+	case isWide(r):
+		return 2
+	default:
+		return 1
+	}
+}
+
+// isWide reports whether r is in one of the [wideRunes] ranges.
+func isWide(r rune) bool {
+	if r < wideRunes[0].lo {
+		return false
+	}
+
+	_, found := slices.BinarySearchFunc(wideRunes[:], r, func(rng runeRange, target rune) int {
+		switch {
+		case rng.hi < target:
+			return -1
+		case rng.lo > target:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	return found
+}
@@ -0,0 +1,37 @@
+package log_test
+
+import (
+	"testing"
+
+	"go.followtheprocess.codes/log"
+	"go.followtheprocess.codes/test"
+)
+
+func TestDisplayWidth(t *testing.T) {
+	tests := []struct {
+		name string // Name of the test case
+		text string // The text to measure
+		want int    // Expected display width
+	}{
+		{name: "empty", text: "", want: 0},
+		{name: "ascii", text: "hello world", want: 11},
+		{name: "ansi", text: "\x1b[1;31mERROR\x1b[0m", want: 5},
+		{name: "ansi only", text: "\x1b[2m\x1b[0m", want: 0},
+		{name: "control characters", text: "a\tb\x7f", want: 2},
+		{name: "accented", text: "café", want: 4},
+		{name: "combining marks", text: "cafe\u0301", want: 4},
+		{name: "cjk", text: "日本語", want: 6},
+		{name: "hangul", text: "한국어", want: 6},
+		{name: "fullwidth", text: "ＡＢ", want: 4},
+		{name: "emoji", text: "🍕🔥", want: 4},
+		{name: "variation selector", text: "\u2764\ufe0f", want: 1},
+		{name: "zero width joiner", text: "a\u200db", want: 2},
+		{name: "mixed", text: "\x1b[35mkey\x1b[0m=日本 🍕", want: 11},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test.Equal(t, log.DisplayWidth(tt.text), tt.want)
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package log
+
+import (
+	"bufio"
+	"os"
+)
+
+// Flusher is implemented by writers that buffer output internally and can push
+// it through to their underlying destination, such as a [bufio.Writer].
+//
+// If a [Logger] writes to a Flusher, [Logger.Sync] will flush it.
+type Flusher interface {
+	Flush() error
+}
+
+// Syncer is implemented by writers that can commit anything written so far to
+// stable storage, such as an [os.File].
+//
+// If a [Logger] writes to a Syncer, [Logger.Sync] will sync it.
+type Syncer interface {
+	Sync() error
+}
+
+// Compile time checks that common writers are picked up by [Logger.Sync].
+var (
+	_ Flusher = (*bufio.Writer)(nil)
+	_ Syncer  = (*os.File)(nil)
+)